@@ -19,8 +19,21 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type RbacInternalServiceClient interface {
 	Authorize(ctx context.Context, in *AuthorizeRequest, opts ...grpc.CallOption) (*AuthorizeResponse, error)
+	// AuthorizeBatch authorizes multiple targets with a single token. The token is
+	// resolved only once.
+	AuthorizeBatch(ctx context.Context, in *AuthorizeBatchRequest, opts ...grpc.CallOption) (*AuthorizeBatchResponse, error)
 	// AuthorizeWorker authorizes requests from worker clusters.
 	AuthorizeWorker(ctx context.Context, in *AuthorizeWorkerRequest, opts ...grpc.CallOption) (*AuthorizeWorkerResponse, error)
+	// ListAPIKeyUsages lists the usages of API keys that have been authorized by this server
+	// instance. The usages are kept in memory, so the caller needs to aggregate the usages
+	// from all the instances.
+	ListAPIKeyUsages(ctx context.Context, in *ListAPIKeyUsagesRequest, opts ...grpc.CallOption) (*ListAPIKeyUsagesResponse, error)
+	// InvalidateAPIKey revokes an API key on this server instance immediately, without waiting
+	// for the next cache sync. The caller needs to call this on all the instances.
+	InvalidateAPIKey(ctx context.Context, in *InvalidateAPIKeyRequest, opts ...grpc.CallOption) (*InvalidateAPIKeyResponse, error)
+	// InvalidateUser revokes a user and the user's API keys on this server instance immediately,
+	// without waiting for the next cache sync. The caller needs to call this on all the instances.
+	InvalidateUser(ctx context.Context, in *InvalidateUserRequest, opts ...grpc.CallOption) (*InvalidateUserResponse, error)
 }
 
 type rbacInternalServiceClient struct {
@@ -40,6 +53,15 @@ func (c *rbacInternalServiceClient) Authorize(ctx context.Context, in *Authorize
 	return out, nil
 }
 
+func (c *rbacInternalServiceClient) AuthorizeBatch(ctx context.Context, in *AuthorizeBatchRequest, opts ...grpc.CallOption) (*AuthorizeBatchResponse, error) {
+	out := new(AuthorizeBatchResponse)
+	err := c.cc.Invoke(ctx, "/llmariner.rbac.server.v1.RbacInternalService/AuthorizeBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *rbacInternalServiceClient) AuthorizeWorker(ctx context.Context, in *AuthorizeWorkerRequest, opts ...grpc.CallOption) (*AuthorizeWorkerResponse, error) {
 	out := new(AuthorizeWorkerResponse)
 	err := c.cc.Invoke(ctx, "/llmariner.rbac.server.v1.RbacInternalService/AuthorizeWorker", in, out, opts...)
@@ -49,13 +71,53 @@ func (c *rbacInternalServiceClient) AuthorizeWorker(ctx context.Context, in *Aut
 	return out, nil
 }
 
+func (c *rbacInternalServiceClient) ListAPIKeyUsages(ctx context.Context, in *ListAPIKeyUsagesRequest, opts ...grpc.CallOption) (*ListAPIKeyUsagesResponse, error) {
+	out := new(ListAPIKeyUsagesResponse)
+	err := c.cc.Invoke(ctx, "/llmariner.rbac.server.v1.RbacInternalService/ListAPIKeyUsages", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rbacInternalServiceClient) InvalidateAPIKey(ctx context.Context, in *InvalidateAPIKeyRequest, opts ...grpc.CallOption) (*InvalidateAPIKeyResponse, error) {
+	out := new(InvalidateAPIKeyResponse)
+	err := c.cc.Invoke(ctx, "/llmariner.rbac.server.v1.RbacInternalService/InvalidateAPIKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rbacInternalServiceClient) InvalidateUser(ctx context.Context, in *InvalidateUserRequest, opts ...grpc.CallOption) (*InvalidateUserResponse, error) {
+	out := new(InvalidateUserResponse)
+	err := c.cc.Invoke(ctx, "/llmariner.rbac.server.v1.RbacInternalService/InvalidateUser", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RbacInternalServiceServer is the server API for RbacInternalService service.
 // All implementations must embed UnimplementedRbacInternalServiceServer
 // for forward compatibility
 type RbacInternalServiceServer interface {
 	Authorize(context.Context, *AuthorizeRequest) (*AuthorizeResponse, error)
+	// AuthorizeBatch authorizes multiple targets with a single token. The token is
+	// resolved only once.
+	AuthorizeBatch(context.Context, *AuthorizeBatchRequest) (*AuthorizeBatchResponse, error)
 	// AuthorizeWorker authorizes requests from worker clusters.
 	AuthorizeWorker(context.Context, *AuthorizeWorkerRequest) (*AuthorizeWorkerResponse, error)
+	// ListAPIKeyUsages lists the usages of API keys that have been authorized by this server
+	// instance. The usages are kept in memory, so the caller needs to aggregate the usages
+	// from all the instances.
+	ListAPIKeyUsages(context.Context, *ListAPIKeyUsagesRequest) (*ListAPIKeyUsagesResponse, error)
+	// InvalidateAPIKey revokes an API key on this server instance immediately, without waiting
+	// for the next cache sync. The caller needs to call this on all the instances.
+	InvalidateAPIKey(context.Context, *InvalidateAPIKeyRequest) (*InvalidateAPIKeyResponse, error)
+	// InvalidateUser revokes a user and the user's API keys on this server instance immediately,
+	// without waiting for the next cache sync. The caller needs to call this on all the instances.
+	InvalidateUser(context.Context, *InvalidateUserRequest) (*InvalidateUserResponse, error)
 	mustEmbedUnimplementedRbacInternalServiceServer()
 }
 
@@ -66,9 +128,21 @@ type UnimplementedRbacInternalServiceServer struct {
 func (UnimplementedRbacInternalServiceServer) Authorize(context.Context, *AuthorizeRequest) (*AuthorizeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Authorize not implemented")
 }
+func (UnimplementedRbacInternalServiceServer) AuthorizeBatch(context.Context, *AuthorizeBatchRequest) (*AuthorizeBatchResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AuthorizeBatch not implemented")
+}
 func (UnimplementedRbacInternalServiceServer) AuthorizeWorker(context.Context, *AuthorizeWorkerRequest) (*AuthorizeWorkerResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method AuthorizeWorker not implemented")
 }
+func (UnimplementedRbacInternalServiceServer) ListAPIKeyUsages(context.Context, *ListAPIKeyUsagesRequest) (*ListAPIKeyUsagesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListAPIKeyUsages not implemented")
+}
+func (UnimplementedRbacInternalServiceServer) InvalidateAPIKey(context.Context, *InvalidateAPIKeyRequest) (*InvalidateAPIKeyResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InvalidateAPIKey not implemented")
+}
+func (UnimplementedRbacInternalServiceServer) InvalidateUser(context.Context, *InvalidateUserRequest) (*InvalidateUserResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method InvalidateUser not implemented")
+}
 func (UnimplementedRbacInternalServiceServer) mustEmbedUnimplementedRbacInternalServiceServer() {}
 
 // UnsafeRbacInternalServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -100,6 +174,24 @@ func _RbacInternalService_Authorize_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RbacInternalService_AuthorizeBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AuthorizeBatchRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RbacInternalServiceServer).AuthorizeBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/llmariner.rbac.server.v1.RbacInternalService/AuthorizeBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RbacInternalServiceServer).AuthorizeBatch(ctx, req.(*AuthorizeBatchRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _RbacInternalService_AuthorizeWorker_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AuthorizeWorkerRequest)
 	if err := dec(in); err != nil {
@@ -118,6 +210,60 @@ func _RbacInternalService_AuthorizeWorker_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RbacInternalService_ListAPIKeyUsages_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListAPIKeyUsagesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RbacInternalServiceServer).ListAPIKeyUsages(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/llmariner.rbac.server.v1.RbacInternalService/ListAPIKeyUsages",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RbacInternalServiceServer).ListAPIKeyUsages(ctx, req.(*ListAPIKeyUsagesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RbacInternalService_InvalidateAPIKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InvalidateAPIKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RbacInternalServiceServer).InvalidateAPIKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/llmariner.rbac.server.v1.RbacInternalService/InvalidateAPIKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RbacInternalServiceServer).InvalidateAPIKey(ctx, req.(*InvalidateAPIKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RbacInternalService_InvalidateUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(InvalidateUserRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RbacInternalServiceServer).InvalidateUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/llmariner.rbac.server.v1.RbacInternalService/InvalidateUser",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RbacInternalServiceServer).InvalidateUser(ctx, req.(*InvalidateUserRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RbacInternalService_ServiceDesc is the grpc.ServiceDesc for RbacInternalService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -129,10 +275,26 @@ var RbacInternalService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Authorize",
 			Handler:    _RbacInternalService_Authorize_Handler,
 		},
+		{
+			MethodName: "AuthorizeBatch",
+			Handler:    _RbacInternalService_AuthorizeBatch_Handler,
+		},
 		{
 			MethodName: "AuthorizeWorker",
 			Handler:    _RbacInternalService_AuthorizeWorker_Handler,
 		},
+		{
+			MethodName: "ListAPIKeyUsages",
+			Handler:    _RbacInternalService_ListAPIKeyUsages_Handler,
+		},
+		{
+			MethodName: "InvalidateAPIKey",
+			Handler:    _RbacInternalService_InvalidateAPIKey_Handler,
+		},
+		{
+			MethodName: "InvalidateUser",
+			Handler:    _RbacInternalService_InvalidateUser_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/v1/rbac_manager_service.proto",
@@ -1,10 +1,27 @@
 import * as fm from "../../fetch.pb";
+export declare enum DenialReason {
+    DENIAL_REASON_UNSPECIFIED = "DENIAL_REASON_UNSPECIFIED",
+    DENIAL_REASON_TOKEN_INACTIVE = "DENIAL_REASON_TOKEN_INACTIVE",
+    DENIAL_REASON_USER_NOT_FOUND = "DENIAL_REASON_USER_NOT_FOUND",
+    DENIAL_REASON_PROJECT_NOT_FOUND = "DENIAL_REASON_PROJECT_NOT_FOUND",
+    DENIAL_REASON_ORGANIZATION_NOT_FOUND = "DENIAL_REASON_ORGANIZATION_NOT_FOUND",
+    DENIAL_REASON_ORGANIZATION_MISMATCH = "DENIAL_REASON_ORGANIZATION_MISMATCH",
+    DENIAL_REASON_ROLE_NOT_FOUND = "DENIAL_REASON_ROLE_NOT_FOUND",
+    DENIAL_REASON_SCOPE_NOT_ALLOWED = "DENIAL_REASON_SCOPE_NOT_ALLOWED",
+    DENIAL_REASON_NOT_PROJECT_MEMBER = "DENIAL_REASON_NOT_PROJECT_MEMBER"
+}
+export declare enum GrantSource {
+    GRANT_SOURCE_UNSPECIFIED = "GRANT_SOURCE_UNSPECIFIED",
+    GRANT_SOURCE_ORGANIZATION_ROLE = "GRANT_SOURCE_ORGANIZATION_ROLE",
+    GRANT_SOURCE_PROJECT_ROLE = "GRANT_SOURCE_PROJECT_ROLE"
+}
 export type AuthorizeRequest = {
     token?: string;
     accessResource?: string;
     capability?: string;
     organizationId?: string;
     projectId?: string;
+    clientIp?: string;
 };
 export type AuthorizeResponse = {
     authorized?: boolean;
@@ -14,6 +31,23 @@ export type AuthorizeResponse = {
     tenantId?: string;
     apiKeyId?: string;
     excludedFromRateLimiting?: boolean;
+    denialReason?: DenialReason;
+    denialDetail?: string;
+    grantSource?: GrantSource;
+};
+export type AuthorizeBatchRequestTarget = {
+    accessResource?: string;
+    capability?: string;
+    organizationId?: string;
+    projectId?: string;
+};
+export type AuthorizeBatchRequest = {
+    token?: string;
+    targets?: AuthorizeBatchRequestTarget[];
+    clientIp?: string;
+};
+export type AuthorizeBatchResponse = {
+    responses?: AuthorizeResponse[];
 };
 export type AuthorizeWorkerRequest = {
     token?: string;
@@ -45,7 +79,31 @@ export type Cluster = {
     id?: string;
     name?: string;
 };
+export type ListAPIKeyUsagesRequest = {
+    tenantId?: string;
+};
+export type APIKeyUsage = {
+    apiKeyId?: string;
+    tenantId?: string;
+    lastUsedAt?: string;
+    requestCount?: string;
+};
+export type ListAPIKeyUsagesResponse = {
+    usages?: APIKeyUsage[];
+};
+export type InvalidateAPIKeyRequest = {
+    apiKeyId?: string;
+};
+export type InvalidateAPIKeyResponse = {};
+export type InvalidateUserRequest = {
+    userId?: string;
+};
+export type InvalidateUserResponse = {};
 export declare class RbacInternalService {
     static Authorize(req: AuthorizeRequest, initReq?: fm.InitReq): Promise<AuthorizeResponse>;
+    static AuthorizeBatch(req: AuthorizeBatchRequest, initReq?: fm.InitReq): Promise<AuthorizeBatchResponse>;
     static AuthorizeWorker(req: AuthorizeWorkerRequest, initReq?: fm.InitReq): Promise<AuthorizeWorkerResponse>;
+    static ListAPIKeyUsages(req: ListAPIKeyUsagesRequest, initReq?: fm.InitReq): Promise<ListAPIKeyUsagesResponse>;
+    static InvalidateAPIKey(req: InvalidateAPIKeyRequest, initReq?: fm.InitReq): Promise<InvalidateAPIKeyResponse>;
+    static InvalidateUser(req: InvalidateUserRequest, initReq?: fm.InitReq): Promise<InvalidateUserResponse>;
 }
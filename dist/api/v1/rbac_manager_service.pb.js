@@ -4,11 +4,41 @@
 * This file is a generated Typescript file for GRPC Gateway, DO NOT MODIFY
 */
 import * as fm from "../../fetch.pb";
+export var DenialReason;
+(function (DenialReason) {
+    DenialReason["DENIAL_REASON_UNSPECIFIED"] = "DENIAL_REASON_UNSPECIFIED";
+    DenialReason["DENIAL_REASON_TOKEN_INACTIVE"] = "DENIAL_REASON_TOKEN_INACTIVE";
+    DenialReason["DENIAL_REASON_USER_NOT_FOUND"] = "DENIAL_REASON_USER_NOT_FOUND";
+    DenialReason["DENIAL_REASON_PROJECT_NOT_FOUND"] = "DENIAL_REASON_PROJECT_NOT_FOUND";
+    DenialReason["DENIAL_REASON_ORGANIZATION_NOT_FOUND"] = "DENIAL_REASON_ORGANIZATION_NOT_FOUND";
+    DenialReason["DENIAL_REASON_ORGANIZATION_MISMATCH"] = "DENIAL_REASON_ORGANIZATION_MISMATCH";
+    DenialReason["DENIAL_REASON_ROLE_NOT_FOUND"] = "DENIAL_REASON_ROLE_NOT_FOUND";
+    DenialReason["DENIAL_REASON_SCOPE_NOT_ALLOWED"] = "DENIAL_REASON_SCOPE_NOT_ALLOWED";
+    DenialReason["DENIAL_REASON_NOT_PROJECT_MEMBER"] = "DENIAL_REASON_NOT_PROJECT_MEMBER";
+})(DenialReason || (DenialReason = {}));
+export var GrantSource;
+(function (GrantSource) {
+    GrantSource["GRANT_SOURCE_UNSPECIFIED"] = "GRANT_SOURCE_UNSPECIFIED";
+    GrantSource["GRANT_SOURCE_ORGANIZATION_ROLE"] = "GRANT_SOURCE_ORGANIZATION_ROLE";
+    GrantSource["GRANT_SOURCE_PROJECT_ROLE"] = "GRANT_SOURCE_PROJECT_ROLE";
+})(GrantSource || (GrantSource = {}));
 export class RbacInternalService {
     static Authorize(req, initReq) {
         return fm.fetchReq(`/llmariner.rbac.server.v1.RbacInternalService/Authorize`, Object.assign(Object.assign({}, initReq), { method: "POST", body: JSON.stringify(req) }));
     }
+    static AuthorizeBatch(req, initReq) {
+        return fm.fetchReq(`/llmariner.rbac.server.v1.RbacInternalService/AuthorizeBatch`, Object.assign(Object.assign({}, initReq), { method: "POST", body: JSON.stringify(req) }));
+    }
     static AuthorizeWorker(req, initReq) {
         return fm.fetchReq(`/llmariner.rbac.server.v1.RbacInternalService/AuthorizeWorker`, Object.assign(Object.assign({}, initReq), { method: "POST", body: JSON.stringify(req) }));
     }
+    static ListAPIKeyUsages(req, initReq) {
+        return fm.fetchReq(`/llmariner.rbac.server.v1.RbacInternalService/ListAPIKeyUsages`, Object.assign(Object.assign({}, initReq), { method: "POST", body: JSON.stringify(req) }));
+    }
+    static InvalidateAPIKey(req, initReq) {
+        return fm.fetchReq(`/llmariner.rbac.server.v1.RbacInternalService/InvalidateAPIKey`, Object.assign(Object.assign({}, initReq), { method: "POST", body: JSON.stringify(req) }));
+    }
+    static InvalidateUser(req, initReq) {
+        return fm.fetchReq(`/llmariner.rbac.server.v1.RbacInternalService/InvalidateUser`, Object.assign(Object.assign({}, initReq), { method: "POST", body: JSON.stringify(req) }));
+    }
 }
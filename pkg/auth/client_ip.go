@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"strings"
+
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+const forwardedForHeader = "X-Forwarded-For"
+
+// clientIPFromContext returns the client IP of a gRPC request. The X-Forwarded-For metadata
+// is set by grpc-gateway and other proxies in front of the server.
+func clientIPFromContext(ctx context.Context, trustedProxyCount int) string {
+	var forwardedFor []string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		forwardedFor = md.Get(forwardedForHeader)
+	}
+	var remoteAddr string
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		remoteAddr = p.Addr.String()
+	}
+	return clientIP(forwardedFor, remoteAddr, trustedProxyCount)
+}
+
+// clientIPFromHTTPRequest returns the client IP of an HTTP request.
+func clientIPFromHTTPRequest(req *http.Request, trustedProxyCount int) string {
+	return clientIP(req.Header.Values(forwardedForHeader), req.RemoteAddr, trustedProxyCount)
+}
+
+// clientIP returns the client IP from the X-Forwarded-For values and the remote address.
+//
+// Each proxy appends the address of its peer to X-Forwarded-For, so the remote address and
+// the last entries of X-Forwarded-For are the addresses of the proxies. The client IP is the
+// entry that was appended by the farthest trusted proxy. Entries before it are set by the
+// client or untrusted proxies and are ignored as they can be spoofed.
+func clientIP(forwardedFor []string, remoteAddr string, trustedProxyCount int) string {
+	var addrs []string
+	for _, v := range forwardedFor {
+		for _, a := range strings.Split(v, ",") {
+			if a = strings.TrimSpace(a); a != "" {
+				addrs = append(addrs, a)
+			}
+		}
+	}
+	if remoteAddr != "" {
+		addrs = append(addrs, remoteAddr)
+	}
+	if len(addrs) == 0 {
+		return ""
+	}
+
+	i := len(addrs) - 1 - trustedProxyCount
+	if i < 0 {
+		i = 0
+	}
+	return stripPort(addrs[i])
+}
+
+func stripPort(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestClientIP(t *testing.T) {
+	tcs := []struct {
+		name              string
+		forwardedFor      []string
+		remoteAddr        string
+		trustedProxyCount int
+		want              string
+	}{
+		{
+			name:       "no proxy",
+			remoteAddr: "10.0.0.1:1234",
+			want:       "10.0.0.1",
+		},
+		{
+			name:         "untrusted X-Forwarded-For",
+			forwardedFor: []string{"1.2.3.4"},
+			remoteAddr:   "10.0.0.1:1234",
+			want:         "10.0.0.1",
+		},
+		{
+			name:              "one trusted proxy",
+			forwardedFor:      []string{"6.6.6.6, 1.2.3.4"},
+			remoteAddr:        "10.0.0.1:1234",
+			trustedProxyCount: 1,
+			want:              "1.2.3.4",
+		},
+		{
+			name:              "two trusted proxies with multiple headers",
+			forwardedFor:      []string{"6.6.6.6, 1.2.3.4", "10.0.0.2"},
+			remoteAddr:        "10.0.0.1:1234",
+			trustedProxyCount: 2,
+			want:              "1.2.3.4",
+		},
+		{
+			name:              "more trusted proxies than addresses",
+			forwardedFor:      []string{"1.2.3.4"},
+			remoteAddr:        "10.0.0.1:1234",
+			trustedProxyCount: 3,
+			want:              "1.2.3.4",
+		},
+		{
+			name:       "ipv6",
+			remoteAddr: "[2001:db8::1]:1234",
+			want:       "2001:db8::1",
+		},
+		{
+			name: "no address",
+			want: "",
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got := clientIP(tc.forwardedFor, tc.remoteAddr, tc.trustedProxyCount)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestClientIPFromContext(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-forwarded-for", "1.2.3.4"))
+	ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("127.0.0.1"), Port: 1234}})
+
+	assert.Equal(t, "127.0.0.1", clientIPFromContext(ctx, 0))
+	assert.Equal(t, "1.2.3.4", clientIPFromContext(ctx, 1))
+}
+
+func TestClientIPFromHTTPRequest(t *testing.T) {
+	req := &http.Request{
+		Header:     http.Header{"X-Forwarded-For": []string{"1.2.3.4"}},
+		RemoteAddr: "10.0.0.1:1234",
+	}
+
+	assert.Equal(t, "10.0.0.1", clientIPFromHTTPRequest(req, 0))
+	assert.Equal(t, "1.2.3.4", clientIPFromHTTPRequest(req, 1))
+}
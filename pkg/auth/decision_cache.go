@@ -0,0 +1,177 @@
+package auth
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	rbacv1 "github.com/llmariner/rbac-manager/api/v1"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	metricNamespace = "llmariner"
+
+	metricsNameDecisionCacheRequests = "rbac_client_decision_cache_requests_total"
+
+	decisionCacheHit  = "hit"
+	decisionCacheMiss = "miss"
+)
+
+// DecisionCacheConfig is the configuration of the cache of authorization decisions.
+//
+// A cached decision is used without calling rbac-server until its TTL expires, so a revoked
+// token or a role change can take up to the TTL to take effect.
+type DecisionCacheConfig struct {
+	// Size is the maximum number of cached decisions. The least recently used decision is
+	// evicted when the cache is full.
+	Size int
+	// PositiveTTL is the TTL of authorized decisions. They are not cached if zero.
+	PositiveTTL time.Duration
+	// NegativeTTL is the TTL of denied decisions. They are not cached if zero.
+	NegativeTTL time.Duration
+
+	// Registerer is used to register the metrics of the cache. prometheus.DefaultRegisterer is
+	// used if nil.
+	Registerer prometheus.Registerer
+}
+
+func (c *DecisionCacheConfig) validate() error {
+	if c.Size <= 0 {
+		return fmt.Errorf("size must be greater than 0")
+	}
+	if c.PositiveTTL < 0 {
+		return fmt.Errorf("positiveTTL must be greater than or equal to 0")
+	}
+	if c.NegativeTTL < 0 {
+		return fmt.Errorf("negativeTTL must be greater than or equal to 0")
+	}
+	return nil
+}
+
+func newDecisionCache(c DecisionCacheConfig) (*decisionCache, error) {
+	requests := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: metricNamespace,
+			Name:      metricsNameDecisionCacheRequests,
+			Help:      "The number of lookups in the authorization decision cache.",
+		},
+		[]string{"result"},
+	)
+	r := c.Registerer
+	if r == nil {
+		r = prometheus.DefaultRegisterer
+	}
+	if err := r.Register(requests); err != nil {
+		// Share the counter with other interceptors in the same process.
+		var are prometheus.AlreadyRegisteredError
+		if !errors.As(err, &are) {
+			return nil, err
+		}
+		requests = are.ExistingCollector.(*prometheus.CounterVec)
+	}
+
+	return &decisionCache{
+		size:        c.Size,
+		positiveTTL: c.PositiveTTL,
+		negativeTTL: c.NegativeTTL,
+		entries:     map[decisionKey]*list.Element{},
+		lru:         list.New(),
+		requests:    requests,
+		now:         time.Now,
+	}, nil
+}
+
+// decisionKey is the key of a cached decision. The token is hashed so that the cache does not
+// hold the tokens.
+type decisionKey [sha256.Size]byte
+
+func newDecisionKey(token, resource, cap, orgID, projectID, clientIP string) decisionKey {
+	h := sha256.New()
+	for _, s := range []string{token, resource, cap, orgID, projectID, clientIP} {
+		// Prefix each field with its length so that different tuples never have the same input.
+		_, _ = fmt.Fprintf(h, "%d:%s", len(s), s)
+	}
+	var k decisionKey
+	h.Sum(k[:0])
+	return k
+}
+
+type decisionEntry struct {
+	key       decisionKey
+	resp      *rbacv1.AuthorizeResponse
+	expiresAt time.Time
+}
+
+// decisionCache is an LRU cache of authorization decisions.
+type decisionCache struct {
+	size        int
+	positiveTTL time.Duration
+	negativeTTL time.Duration
+
+	mu      sync.Mutex
+	entries map[decisionKey]*list.Element
+	// lru holds *decisionEntry. The front is the most recently used one.
+	lru *list.List
+
+	requests *prometheus.CounterVec
+
+	// now returns the current time. It is replaced in tests.
+	now func() time.Time
+}
+
+func (c *decisionCache) get(key decisionKey) (*rbacv1.AuthorizeResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.entries[key]
+	if !ok {
+		c.requests.WithLabelValues(decisionCacheMiss).Inc()
+		return nil, false
+	}
+	de := e.Value.(*decisionEntry)
+	if !c.now().Before(de.expiresAt) {
+		c.remove(e)
+		c.requests.WithLabelValues(decisionCacheMiss).Inc()
+		return nil, false
+	}
+	c.lru.MoveToFront(e)
+	c.requests.WithLabelValues(decisionCacheHit).Inc()
+	return de.resp, true
+}
+
+func (c *decisionCache) put(key decisionKey, resp *rbacv1.AuthorizeResponse) {
+	ttl := c.negativeTTL
+	if resp.Authorized {
+		ttl = c.positiveTTL
+	}
+	if ttl == 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	de := &decisionEntry{
+		key:       key,
+		resp:      resp,
+		expiresAt: c.now().Add(ttl),
+	}
+	if e, ok := c.entries[key]; ok {
+		e.Value = de
+		c.lru.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.lru.PushFront(de)
+	for c.lru.Len() > c.size {
+		c.remove(c.lru.Back())
+	}
+}
+
+func (c *decisionCache) remove(e *list.Element) {
+	c.lru.Remove(e)
+	delete(c.entries, e.Value.(*decisionEntry).key)
+}
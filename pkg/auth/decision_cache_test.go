@@ -0,0 +1,119 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "github.com/llmariner/rbac-manager/api/v1"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestDecisionCache(t *testing.T) {
+	c, err := newDecisionCache(DecisionCacheConfig{
+		Size:        2,
+		PositiveTTL: time.Minute,
+		NegativeTTL: 10 * time.Second,
+		Registerer:  prometheus.NewRegistry(),
+	})
+	assert.NoError(t, err)
+	now := time.Now()
+	c.now = func() time.Time { return now }
+
+	allowed := &v1.AuthorizeResponse{Authorized: true}
+	denied := &v1.AuthorizeResponse{Authorized: false}
+
+	k0 := newDecisionKey("t0", "r", "read", "o0", "p0", "")
+	k1 := newDecisionKey("t1", "r", "read", "o0", "p0", "")
+	k2 := newDecisionKey("t2", "r", "read", "o0", "p0", "")
+	assert.NotEqual(t, k0, k1)
+
+	_, ok := c.get(k0)
+	assert.False(t, ok)
+
+	c.put(k0, allowed)
+	c.put(k1, denied)
+	resp, ok := c.get(k0)
+	assert.True(t, ok)
+	assert.Same(t, allowed, resp)
+	resp, ok = c.get(k1)
+	assert.True(t, ok)
+	assert.Same(t, denied, resp)
+
+	// The denied decision expires first.
+	now = now.Add(30 * time.Second)
+	_, ok = c.get(k1)
+	assert.False(t, ok)
+	_, ok = c.get(k0)
+	assert.True(t, ok)
+
+	// The least recently used decision is evicted.
+	c.put(k1, denied)
+	_, ok = c.get(k0)
+	assert.True(t, ok)
+	c.put(k2, allowed)
+	_, ok = c.get(k1)
+	assert.False(t, ok)
+	_, ok = c.get(k0)
+	assert.True(t, ok)
+	_, ok = c.get(k2)
+	assert.True(t, ok)
+
+	assert.Equal(t, 6.0, testutil.ToFloat64(c.requests.WithLabelValues(decisionCacheHit)))
+	assert.Equal(t, 3.0, testutil.ToFloat64(c.requests.WithLabelValues(decisionCacheMiss)))
+}
+
+func TestDecisionCache_ZeroTTL(t *testing.T) {
+	c, err := newDecisionCache(DecisionCacheConfig{
+		Size:        10,
+		PositiveTTL: time.Minute,
+		Registerer:  prometheus.NewRegistry(),
+	})
+	assert.NoError(t, err)
+
+	k := newDecisionKey("t0", "r", "read", "o0", "p0", "")
+	c.put(k, &v1.AuthorizeResponse{Authorized: false})
+	_, ok := c.get(k)
+	assert.False(t, ok)
+}
+
+func TestUnary_DecisionCache(t *testing.T) {
+	client := &fakeInternalServerClient{
+		t:              t,
+		wantResource:   "test.resource",
+		wantCapability: "read",
+	}
+	dc, err := newDecisionCache(DecisionCacheConfig{
+		Size:        10,
+		PositiveTTL: time.Minute,
+		Registerer:  prometheus.NewRegistry(),
+	})
+	assert.NoError(t, err)
+	interceptor := &Interceptor{
+		client: client,
+		getAccessResourceForGRPCRequest: func(fullMethod string) string {
+			return "test.resource"
+		},
+		decisionCache: dc,
+	}
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.server/GetTest"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer token"))
+	for i := 0; i < 2; i++ {
+		_, err := interceptor.Unary()(ctx, nil, info, handler)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, 1, client.counter)
+
+	// A different token is not served from the cache.
+	ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer token1"))
+	_, err = interceptor.Unary()(ctx, nil, info, handler)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, client.counter)
+}
@@ -8,17 +8,26 @@ import (
 	"strings"
 
 	rbacv1 "github.com/llmariner/rbac-manager/api/v1"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoregistry"
 )
 
+// Capabilities of requests. rbac-server authorizes a request with the "<resource>.<capability>" scope.
 const (
-	capRead  = "read"
-	capWrite = "write"
+	CapabilityRead  = "read"
+	CapabilityWrite = "write"
+	// CapabilityDelete and CapabilityAdmin are not used by default. They can be returned from
+	// the GetCapability functions in Config.
+	CapabilityDelete = "delete"
+	CapabilityAdmin  = "admin"
+)
 
+const (
 	authHeader = "Authorization"
 	// orgHeader is the header key for organization ID.
 	// The header defined in https://platform.openai.com/docs/api-reference/authentication
@@ -26,6 +35,9 @@ const (
 	orgHeader = "Openai-Organization"
 	// projectHeader is the header key for project ID.
 	projectHeader = "Openai-Project"
+
+	// errorInfoDomain is the domain set in the ErrorInfo of a permission denied error.
+	errorInfoDomain = "rbac.llmariner.ai"
 )
 
 // Config is the configuration for an Interceptor.
@@ -38,6 +50,31 @@ type Config struct {
 	GetAccessResourceForGRPCRequest func(fullMethod string) string
 	// GetAccessResourceForHTTPRequest is a function to get the resource name from an HTTP request method and URL.
 	GetAccessResourceForHTTPRequest func(method string, url url.URL) string
+
+	// GetCapabilityForGRPCRequest is a function to get the capability from a gRPC method.
+	// DefaultCapabilityForGRPCRequest is used if nil.
+	GetCapabilityForGRPCRequest func(fullMethod string) string
+	// GetCapabilityForHTTPRequest is a function to get the capability from an HTTP request method and URL.
+	// DefaultCapabilityForHTTPRequest is used if nil.
+	GetCapabilityForHTTPRequest func(method string, url url.URL) string
+
+	// ScopedServices is a list of the full names of gRPC services, such as
+	// "llmariner.files.server.v1.FilesService", whose RPCs are annotated with the
+	// (llmariner.rbac.required_scope) option defined in api/v1/options.proto. The resource and the capability of an RPC of these services are taken from the
+	// option, and NewInterceptor returns an error if any RPC is not annotated.
+	//
+	// The services must be registered in protoregistry.GlobalFiles, which is done by importing
+	// their generated Go packages.
+	ScopedServices []string
+
+	// TrustedProxyCount is the number of proxies in front of the server that append the client
+	// address to X-Forwarded-For. The client IP is taken from the entry appended by the farthest
+	// of them. If zero, X-Forwarded-For is ignored and the peer address is used. Note that
+	// grpc-gateway counts as a proxy for the gRPC requests it forwards.
+	TrustedProxyCount int
+
+	// DecisionCache enables the cache of authorization decisions if set.
+	DecisionCache *DecisionCacheConfig
 }
 
 // NewInterceptor creates a new Interceptor.
@@ -46,12 +83,32 @@ func NewInterceptor(ctx context.Context, c Config) (*Interceptor, error) {
 	if err != nil {
 		return nil, err
 	}
-	i := &Interceptor{client: rbacv1.NewRbacInternalServiceClient(conn)}
+	if c.TrustedProxyCount < 0 {
+		return nil, fmt.Errorf("TrustedProxyCount must be greater than or equal to 0")
+	}
+	i := &Interceptor{
+		client:            rbacv1.NewRbacInternalServiceClient(conn),
+		trustedProxyCount: c.TrustedProxyCount,
+	}
+	if dc := c.DecisionCache; dc != nil {
+		if err := dc.validate(); err != nil {
+			return nil, fmt.Errorf("DecisionCache: %s", err)
+		}
+		if i.decisionCache, err = newDecisionCache(*dc); err != nil {
+			return nil, err
+		}
+	}
 
 	if c.AccessResource == "" &&
 		c.GetAccessResourceForGRPCRequest == nil &&
-		c.GetAccessResourceForHTTPRequest == nil {
-		return nil, fmt.Errorf("AccessResource or GetAccessResource functions must be set")
+		c.GetAccessResourceForHTTPRequest == nil &&
+		len(c.ScopedServices) == 0 {
+		return nil, fmt.Errorf("AccessResource, GetAccessResource functions, or ScopedServices must be set")
+	}
+	if len(c.ScopedServices) > 0 {
+		if i.requiredScopes, err = requiredScopesFromServices(protoregistry.GlobalFiles, c.ScopedServices); err != nil {
+			return nil, err
+		}
 	}
 	if c.AccessResource != "" {
 		i.getAccessResourceForGRPCRequest = func(string) string { return c.AccessResource }
@@ -60,6 +117,8 @@ func NewInterceptor(ctx context.Context, c Config) (*Interceptor, error) {
 		i.getAccessResourceForGRPCRequest = c.GetAccessResourceForGRPCRequest
 		i.getAccessResourceForHTTPRequest = c.GetAccessResourceForHTTPRequest
 	}
+	i.getCapabilityForGRPCRequest = c.GetCapabilityForGRPCRequest
+	i.getCapabilityForHTTPRequest = c.GetCapabilityForHTTPRequest
 
 	return i, nil
 }
@@ -70,6 +129,18 @@ type Interceptor struct {
 
 	getAccessResourceForGRPCRequest func(fullMethod string) string
 	getAccessResourceForHTTPRequest func(method string, url url.URL) string
+
+	// requiredScopes maps the full method name of an RPC of the scoped services to its required scope.
+	requiredScopes map[string]requiredScope
+
+	// getCapabilityForGRPCRequest and getCapabilityForHTTPRequest are nil if the default is used.
+	getCapabilityForGRPCRequest func(fullMethod string) string
+	getCapabilityForHTTPRequest func(method string, url url.URL) string
+
+	trustedProxyCount int
+
+	// decisionCache is nil if the cache is disabled.
+	decisionCache *decisionCache
 }
 
 // Unary returns a unary server interceptor.
@@ -81,44 +152,81 @@ func (a *Interceptor) Unary(excludeMethods ...string) grpc.UnaryServerIntercepto
 			}
 		}
 
-		token, err := ExtractTokenFromContext(ctx)
+		ctx, err = a.authorizeGRPCRequest(ctx, info.FullMethod)
 		if err != nil {
 			return nil, err
 		}
+		return handler(ctx, req)
+	}
+}
 
-		ms := strings.Split(info.FullMethod, "/")
-		method := ms[len(ms)-1]
-
-		var cap string
-		switch {
-		case strings.HasPrefix(method, "Get"),
-			strings.HasPrefix(method, "List"):
-			cap = capRead
-		default:
-			cap = capWrite
+// Stream returns a stream server interceptor.
+func (a *Interceptor) Stream(excludeMethods ...string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		for _, m := range excludeMethods {
+			if info.FullMethod == m {
+				return handler(srv, ss)
+			}
 		}
 
-		orgID := extractOrgIDFromContext(ctx)
-		projectID := extractProjectIDFromContext(ctx)
+		ctx, err := a.authorizeGRPCRequest(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+	}
+}
 
-		resource := a.getAccessResourceForGRPCRequest(info.FullMethod)
+// authorizeGRPCRequest authorizes a gRPC request and returns a context that has the user info.
+func (a *Interceptor) authorizeGRPCRequest(ctx context.Context, fullMethod string) (context.Context, error) {
+	token, err := ExtractTokenFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
 
-		aresp, err := a.authorize(ctx, token, resource, cap, orgID, projectID)
-		if err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to authorize: %v", err)
+	var resource, cap string
+	if rs, ok := a.requiredScopes[fullMethod]; ok {
+		resource, cap = rs.resource, rs.capability
+	} else {
+		if a.getAccessResourceForGRPCRequest == nil {
+			// Only the scoped services are configured.
+			return nil, status.Errorf(codes.PermissionDenied, "no required scope is declared for %s", fullMethod)
 		}
-		if !aresp.Authorized {
-			return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+		resource = a.getAccessResourceForGRPCRequest(fullMethod)
+		cap = DefaultCapabilityForGRPCRequest(fullMethod)
+		if f := a.getCapabilityForGRPCRequest; f != nil {
+			cap = f(fullMethod)
 		}
+	}
 
-		// TODO(aya): revisit this after implement org management
-		ctx = AppendUserInfoToContext(ctx, newUserInfoFromAuthorizeResponse(aresp))
-		return handler(ctx, req)
+	orgID := extractOrgIDFromContext(ctx)
+	projectID := extractProjectIDFromContext(ctx)
+
+	clientIP := clientIPFromContext(ctx, a.trustedProxyCount)
+
+	aresp, err := a.authorize(ctx, token, resource, cap, orgID, projectID, clientIP)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to authorize: %v", err)
+	}
+	if !aresp.Authorized {
+		return nil, newPermissionDeniedStatusError(aresp)
 	}
+
+	// TODO(aya): revisit this after implement org management
+	return AppendUserInfoToContext(ctx, newUserInfoFromAuthorizeResponse(aresp)), nil
 }
 
 // InterceptHTTPRequest intercepts an HTTP request and returns an HTTP status code.
 func (a *Interceptor) InterceptHTTPRequest(req *http.Request) (int, UserInfo, error) {
+	if a.getAccessResourceForHTTPRequest == nil {
+		// Only the scoped services are configured.
+		return http.StatusInternalServerError, UserInfo{}, fmt.Errorf("access resource for HTTP requests is not configured")
+	}
+	return a.interceptHTTPRequest(req, a.getAccessResourceForHTTPRequest(req.Method, *req.URL))
+}
+
+// interceptHTTPRequest intercepts an HTTP request that accesses the given resource.
+func (a *Interceptor) interceptHTTPRequest(req *http.Request, resource string) (int, UserInfo, error) {
 	token, found := extractTokenFromHeader(req.Header)
 	if !found {
 		return http.StatusUnauthorized, UserInfo{}, fmt.Errorf("missing authorization")
@@ -127,22 +235,19 @@ func (a *Interceptor) InterceptHTTPRequest(req *http.Request) (int, UserInfo, er
 	orgID := extractOrgIDFromHeader(req.Header)
 	projectID := extractProjectIDFromHeader(req.Header)
 
-	var cap string
-	switch req.Method {
-	case http.MethodGet:
-		cap = capRead
-	default:
-		cap = capWrite
+	cap := DefaultCapabilityForHTTPRequest(req.Method, *req.URL)
+	if f := a.getCapabilityForHTTPRequest; f != nil {
+		cap = f(req.Method, *req.URL)
 	}
 
-	resource := a.getAccessResourceForHTTPRequest(req.Method, *req.URL)
+	clientIP := clientIPFromHTTPRequest(req, a.trustedProxyCount)
 
-	resp, err := a.authorize(req.Context(), token, resource, cap, orgID, projectID)
+	resp, err := a.authorize(req.Context(), token, resource, cap, orgID, projectID, clientIP)
 	if err != nil {
 		return http.StatusInternalServerError, UserInfo{}, fmt.Errorf("failed to authorize: %v", err)
 	}
 	if !resp.Authorized {
-		return http.StatusUnauthorized, UserInfo{}, fmt.Errorf("permission denied")
+		return http.StatusUnauthorized, UserInfo{}, newPermissionDeniedError(resp)
 	}
 
 	// TODO(kenji): Return user info.
@@ -150,6 +255,32 @@ func (a *Interceptor) InterceptHTTPRequest(req *http.Request) (int, UserInfo, er
 	return http.StatusOK, newUserInfoFromAuthorizeResponse(resp), nil
 }
 
+// DefaultCapabilityForGRPCRequest returns the capability of a gRPC method. It is
+// CapabilityRead if the method name starts with "Get" or "List", and CapabilityWrite otherwise.
+func DefaultCapabilityForGRPCRequest(fullMethod string) string {
+	ms := strings.Split(fullMethod, "/")
+	method := ms[len(ms)-1]
+
+	switch {
+	case strings.HasPrefix(method, "Get"),
+		strings.HasPrefix(method, "List"):
+		return CapabilityRead
+	default:
+		return CapabilityWrite
+	}
+}
+
+// DefaultCapabilityForHTTPRequest returns the capability of an HTTP request. It is
+// CapabilityRead if the method is GET, and CapabilityWrite otherwise.
+func DefaultCapabilityForHTTPRequest(method string, url url.URL) string {
+	switch method {
+	case http.MethodGet:
+		return CapabilityRead
+	default:
+		return CapabilityWrite
+	}
+}
+
 func (a *Interceptor) authorize(
 	ctx context.Context,
 	token string,
@@ -157,14 +288,74 @@ func (a *Interceptor) authorize(
 	cap string,
 	orgID string,
 	projectID string,
+	clientIP string,
 ) (*rbacv1.AuthorizeResponse, error) {
-	return a.client.Authorize(ctx, &rbacv1.AuthorizeRequest{
+	var key decisionKey
+	if a.decisionCache != nil {
+		key = newDecisionKey(token, resource, cap, orgID, projectID, clientIP)
+		if resp, ok := a.decisionCache.get(key); ok {
+			return resp, nil
+		}
+	}
+
+	resp, err := a.client.Authorize(ctx, &rbacv1.AuthorizeRequest{
 		Token:          token,
 		AccessResource: resource,
 		Capability:     cap,
 		OrganizationId: orgID,
 		ProjectId:      projectID,
+		ClientIp:       clientIP,
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	if a.decisionCache != nil {
+		a.decisionCache.put(key, resp)
+	}
+	return resp, nil
+}
+
+// PermissionDeniedError is returned when rbac-server does not authorize a request.
+type PermissionDeniedError struct {
+	// Reason is the name of the rbacv1.DenialReason.
+	Reason string
+	// Detail is a human-readable description of the denial.
+	Detail string
+}
+
+func newPermissionDeniedError(resp *rbacv1.AuthorizeResponse) *PermissionDeniedError {
+	return &PermissionDeniedError{
+		Reason: resp.DenialReason.String(),
+		Detail: resp.DenialDetail,
+	}
+}
+
+// Error implements the error interface.
+func (e *PermissionDeniedError) Error() string {
+	if e.Detail == "" {
+		return "permission denied"
+	}
+	return fmt.Sprintf("permission denied: %s (%s)", e.Detail, e.Reason)
+}
+
+// newPermissionDeniedStatusError returns a gRPC status error that carries the denial reason
+// as an ErrorInfo detail.
+func newPermissionDeniedStatusError(resp *rbacv1.AuthorizeResponse) error {
+	e := newPermissionDeniedError(resp)
+	st := status.New(codes.PermissionDenied, e.Error())
+	if resp.DenialReason == rbacv1.DenialReason_DENIAL_REASON_UNSPECIFIED {
+		return st.Err()
+	}
+	sd, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason:   e.Reason,
+		Domain:   errorInfoDomain,
+		Metadata: map[string]string{"detail": e.Detail},
+	})
+	if err != nil {
+		return st.Err()
+	}
+	return sd.Err()
 }
 
 // ExtractTokenFromContext extracts a token from a context.
@@ -2,14 +2,18 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/url"
 	"testing"
 
 	v1 "github.com/llmariner/rbac-manager/api/v1"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 )
 
 func TestNewInterceptor(t *testing.T) {
@@ -89,12 +93,53 @@ func TestUnary(t *testing.T) {
 	assert.Equal(t, 1, client.counter)
 }
 
+func TestStream(t *testing.T) {
+	client := &fakeInternalServerClient{
+		t:              t,
+		wantResource:   "test.resource",
+		wantCapability: "read",
+	}
+	interceptor := &Interceptor{
+		client: client,
+		getAccessResourceForGRPCRequest: func(fullMethod string) string {
+			return "test.resource"
+		},
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer token"))
+	ss := &serverStream{ctx: ctx}
+	info := &grpc.StreamServerInfo{FullMethod: "/test.server/ListTests", IsServerStream: true}
+	var userInfo *UserInfo
+	handler := func(srv interface{}, ss grpc.ServerStream) error {
+		userInfo, _ = ExtractUserInfoFromContext(ss.Context())
+		return nil
+	}
+
+	err := interceptor.Stream(info.FullMethod)(nil, ss, info, handler)
+	assert.NoError(t, err)
+	assert.Nil(t, userInfo)
+	assert.Equal(t, 0, client.counter)
+
+	err = interceptor.Stream()(nil, ss, info, handler)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, client.counter)
+	assert.NotNil(t, userInfo)
+	assert.Equal(t, "u0", userInfo.UserID)
+
+	// The request is rejected without a token.
+	ss = &serverStream{ctx: metadata.NewIncomingContext(context.Background(), metadata.MD{})}
+	err = interceptor.Stream()(nil, ss, info, handler)
+	assert.Error(t, err)
+	assert.Equal(t, 1, client.counter)
+}
+
 func TestInterceptHTTPRequest(t *testing.T) {
 	interceptor := &Interceptor{
 		client: &fakeInternalServerClient{
 			t:              t,
 			wantResource:   "resource",
 			wantCapability: "read",
+			wantClientIP:   "10.0.0.1",
 		},
 		getAccessResourceForHTTPRequest: func(method string, u url.URL) string {
 			return "resource"
@@ -102,9 +147,10 @@ func TestInterceptHTTPRequest(t *testing.T) {
 	}
 
 	req := &http.Request{
-		Method: http.MethodGet,
-		Header: http.Header{"Authorization": []string{"Bearer token"}},
-		URL:    &url.URL{},
+		Method:     http.MethodGet,
+		Header:     http.Header{"Authorization": []string{"Bearer token"}},
+		URL:        &url.URL{},
+		RemoteAddr: "10.0.0.1:1234",
 	}
 
 	statusCode, userInfo, err := interceptor.InterceptHTTPRequest(req)
@@ -113,11 +159,125 @@ func TestInterceptHTTPRequest(t *testing.T) {
 	assert.NotNil(t, userInfo)
 }
 
+func TestCapability(t *testing.T) {
+	client := &fakeInternalServerClient{
+		t:              t,
+		wantResource:   "resource",
+		wantCapability: "read",
+	}
+	interceptor := &Interceptor{
+		client: client,
+		getAccessResourceForGRPCRequest: func(fullMethod string) string {
+			return "resource"
+		},
+		getAccessResourceForHTTPRequest: func(method string, u url.URL) string {
+			return "resource"
+		},
+		getCapabilityForGRPCRequest: func(fullMethod string) string {
+			if fullMethod == "/test.server/SearchTests" {
+				return CapabilityRead
+			}
+			return DefaultCapabilityForGRPCRequest(fullMethod)
+		},
+		getCapabilityForHTTPRequest: func(method string, u url.URL) string {
+			if method == http.MethodHead {
+				return CapabilityRead
+			}
+			return DefaultCapabilityForHTTPRequest(method, u)
+		},
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer token"))
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.server/SearchTests"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	_, err := interceptor.Unary()(ctx, nil, info, handler)
+	assert.NoError(t, err)
+
+	req := &http.Request{
+		Method: http.MethodHead,
+		Header: http.Header{"Authorization": []string{"Bearer token"}},
+		URL:    &url.URL{},
+	}
+	statusCode, _, err := interceptor.InterceptHTTPRequest(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, statusCode)
+
+	client.wantCapability = CapabilityDelete
+	interceptor.getCapabilityForGRPCRequest = func(fullMethod string) string {
+		return CapabilityDelete
+	}
+	info = &grpc.UnaryServerInfo{FullMethod: "/test.server/DeleteTest"}
+	_, err = interceptor.Unary()(ctx, nil, info, handler)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, client.counter)
+}
+
+func TestDefaultCapability(t *testing.T) {
+	assert.Equal(t, CapabilityRead, DefaultCapabilityForGRPCRequest("/test.server/GetTest"))
+	assert.Equal(t, CapabilityRead, DefaultCapabilityForGRPCRequest("/test.server/ListTests"))
+	assert.Equal(t, CapabilityWrite, DefaultCapabilityForGRPCRequest("/test.server/CreateTest"))
+	assert.Equal(t, CapabilityRead, DefaultCapabilityForHTTPRequest(http.MethodGet, url.URL{}))
+	assert.Equal(t, CapabilityWrite, DefaultCapabilityForHTTPRequest(http.MethodPost, url.URL{}))
+}
+
+func TestPermissionDenied(t *testing.T) {
+	client := &fakeInternalServerClient{
+		t:              t,
+		wantResource:   "resource",
+		wantCapability: "read",
+		resp: &v1.AuthorizeResponse{
+			Authorized:   false,
+			DenialReason: v1.DenialReason_DENIAL_REASON_PROJECT_NOT_FOUND,
+			DenialDetail: `project "p0" not found`,
+		},
+	}
+	interceptor := &Interceptor{
+		client: client,
+		getAccessResourceForGRPCRequest: func(fullMethod string) string {
+			return "resource"
+		},
+		getAccessResourceForHTTPRequest: func(method string, u url.URL) string {
+			return "resource"
+		},
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer token"))
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.server/GetTest"}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+	_, err := interceptor.Unary()(ctx, nil, info, handler)
+	assert.Error(t, err)
+	st, ok := status.FromError(err)
+	assert.True(t, ok)
+	assert.Equal(t, codes.PermissionDenied, st.Code())
+	details := st.Details()
+	assert.Len(t, details, 1)
+	ei, ok := details[0].(*errdetails.ErrorInfo)
+	assert.True(t, ok)
+	assert.Equal(t, "DENIAL_REASON_PROJECT_NOT_FOUND", ei.Reason)
+	assert.Equal(t, `project "p0" not found`, ei.Metadata["detail"])
+
+	req := &http.Request{
+		Method: http.MethodGet,
+		Header: http.Header{"Authorization": []string{"Bearer token"}},
+		URL:    &url.URL{},
+	}
+	statusCode, _, err := interceptor.InterceptHTTPRequest(req)
+	assert.Equal(t, http.StatusUnauthorized, statusCode)
+	var pde *PermissionDeniedError
+	assert.True(t, errors.As(err, &pde))
+	assert.Equal(t, "DENIAL_REASON_PROJECT_NOT_FOUND", pde.Reason)
+	assert.Equal(t, `permission denied: project "p0" not found (DENIAL_REASON_PROJECT_NOT_FOUND)`, err.Error())
+}
+
 type fakeInternalServerClient struct {
 	t *testing.T
 
 	wantResource   string
 	wantCapability string
+	wantClientIP   string
+
+	// resp is returned from Authorize if set.
+	resp *v1.AuthorizeResponse
 
 	counter int
 }
@@ -125,8 +285,12 @@ type fakeInternalServerClient struct {
 func (f *fakeInternalServerClient) Authorize(ctx context.Context, in *v1.AuthorizeRequest, opts ...grpc.CallOption) (*v1.AuthorizeResponse, error) {
 	assert.Equal(f.t, f.wantResource, in.AccessResource)
 	assert.Equal(f.t, f.wantCapability, in.Capability)
+	assert.Equal(f.t, f.wantClientIP, in.ClientIp)
 
 	f.counter++
+	if f.resp != nil {
+		return f.resp, nil
+	}
 	return &v1.AuthorizeResponse{
 		Authorized:               true,
 		User:                     &v1.User{Id: "u0"},
@@ -136,9 +300,29 @@ func (f *fakeInternalServerClient) Authorize(ctx context.Context, in *v1.Authori
 	}, nil
 }
 
+func (f *fakeInternalServerClient) AuthorizeBatch(ctx context.Context, in *v1.AuthorizeBatchRequest, opts ...grpc.CallOption) (*v1.AuthorizeBatchResponse, error) {
+	var resps []*v1.AuthorizeResponse
+	for range in.Targets {
+		resps = append(resps, &v1.AuthorizeResponse{Authorized: true})
+	}
+	return &v1.AuthorizeBatchResponse{Responses: resps}, nil
+}
+
 func (f *fakeInternalServerClient) AuthorizeWorker(ctx context.Context, in *v1.AuthorizeWorkerRequest, opts ...grpc.CallOption) (*v1.AuthorizeWorkerResponse, error) {
 	return &v1.AuthorizeWorkerResponse{
 		Authorized: true,
 		Cluster:    &v1.Cluster{Id: "c0"},
 	}, nil
 }
+
+func (f *fakeInternalServerClient) ListAPIKeyUsages(ctx context.Context, in *v1.ListAPIKeyUsagesRequest, opts ...grpc.CallOption) (*v1.ListAPIKeyUsagesResponse, error) {
+	return &v1.ListAPIKeyUsagesResponse{}, nil
+}
+
+func (f *fakeInternalServerClient) InvalidateAPIKey(ctx context.Context, in *v1.InvalidateAPIKeyRequest, opts ...grpc.CallOption) (*v1.InvalidateAPIKeyResponse, error) {
+	return &v1.InvalidateAPIKeyResponse{}, nil
+}
+
+func (f *fakeInternalServerClient) InvalidateUser(ctx context.Context, in *v1.InvalidateUserRequest, opts ...grpc.CallOption) (*v1.InvalidateUserResponse, error) {
+	return &v1.InvalidateUserResponse{}, nil
+}
@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+
+	rbacv1 "github.com/llmariner/rbac-manager/api/v1"
+)
+
+// HTTPMiddlewareOpts is the options for Interceptor.HTTPMiddleware.
+type HTTPMiddlewareOpts struct {
+	// ExcludePaths is a list of URL paths that are served without authorization.
+	ExcludePaths []string
+	// GetAccessResource is a function to get the resource name from an HTTP request. If nil,
+	// the resource is determined by the Config of the Interceptor.
+	GetAccessResource func(req *http.Request) string
+}
+
+// HTTPMiddleware returns an HTTP middleware that authorizes requests and appends the user
+// info to the request context. An unauthorized request gets an error response in the
+// OpenAI-compatible format.
+func (a *Interceptor) HTTPMiddleware(opts HTTPMiddlewareOpts) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if slices.Contains(opts.ExcludePaths, req.URL.Path) {
+				next.ServeHTTP(w, req)
+				return
+			}
+
+			var (
+				code     int
+				userInfo UserInfo
+				err      error
+			)
+			if f := opts.GetAccessResource; f != nil {
+				code, userInfo, err = a.interceptHTTPRequest(req, f(req))
+			} else {
+				code, userInfo, err = a.InterceptHTTPRequest(req)
+			}
+			if err != nil {
+				writeErrorResponse(w, code, err)
+				return
+			}
+			next.ServeHTTP(w, req.WithContext(AppendUserInfoToContext(req.Context(), userInfo)))
+		})
+	}
+}
+
+// HTTPMiddleware returns an HTTP middleware that authorizes requests from worker clusters
+// and appends the cluster info to the request context. Requests to excludePaths are served
+// without authorization.
+func (a *WorkerInterceptor) HTTPMiddleware(excludePaths ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if slices.Contains(excludePaths, req.URL.Path) {
+				next.ServeHTTP(w, req)
+				return
+			}
+
+			code, clusterInfo, err := a.InterceptHTTPRequest(req)
+			if err != nil {
+				writeErrorResponse(w, code, err)
+				return
+			}
+			next.ServeHTTP(w, req.WithContext(AppendClusterInfoToContext(req.Context(), clusterInfo)))
+		})
+	}
+}
+
+// errorResponse is an error response in the OpenAI-compatible format.
+type errorResponse struct {
+	Error errorDetail `json:"error"`
+}
+
+type errorDetail struct {
+	Message string  `json:"message"`
+	Type    string  `json:"type"`
+	Param   *string `json:"param"`
+	Code    *string `json:"code"`
+}
+
+func writeErrorResponse(w http.ResponseWriter, code int, err error) {
+	d := errorDetail{
+		Message: err.Error(),
+		Type:    "invalid_request_error",
+	}
+	if code >= http.StatusInternalServerError {
+		d.Type = "server_error"
+	}
+	var pde *PermissionDeniedError
+	if errors.As(err, &pde) && pde.Reason != rbacv1.DenialReason_DENIAL_REASON_UNSPECIFIED.String() {
+		d.Code = &pde.Reason
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(&errorResponse{Error: d})
+}
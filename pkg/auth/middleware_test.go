@@ -0,0 +1,116 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	v1 "github.com/llmariner/rbac-manager/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPMiddleware(t *testing.T) {
+	client := &fakeInternalServerClient{
+		t:              t,
+		wantResource:   "api.files",
+		wantCapability: "read",
+		// The default remote address of httptest.NewRequest.
+		wantClientIP: "192.0.2.1",
+	}
+	interceptor := &Interceptor{
+		client: client,
+		getAccessResourceForHTTPRequest: func(method string, u url.URL) string {
+			return "resource"
+		},
+	}
+
+	var userInfo *UserInfo
+	next := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		userInfo, _ = ExtractUserInfoFromContext(req.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+	h := interceptor.HTTPMiddleware(HTTPMiddlewareOpts{
+		ExcludePaths: []string{"/healthz"},
+		GetAccessResource: func(req *http.Request) string {
+			return "api.files"
+		},
+	})(next)
+
+	// Authorized.
+	req := httptest.NewRequest(http.MethodGet, "/v1/files", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotNil(t, userInfo)
+	assert.Equal(t, "u0", userInfo.UserID)
+	assert.Equal(t, 1, client.counter)
+
+	// Excluded.
+	userInfo = nil
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Nil(t, userInfo)
+	assert.Equal(t, 1, client.counter)
+
+	// Missing authorization.
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/files", nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var resp errorResponse
+	err := json.Unmarshal(rec.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, "missing authorization", resp.Error.Message)
+	assert.Equal(t, "invalid_request_error", resp.Error.Type)
+	assert.Nil(t, resp.Error.Code)
+
+	// Permission denied.
+	client.resp = &v1.AuthorizeResponse{
+		Authorized:   false,
+		DenialReason: v1.DenialReason_DENIAL_REASON_PROJECT_NOT_FOUND,
+		DenialDetail: `project "p0" not found`,
+	}
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	resp = errorResponse{}
+	err = json.Unmarshal(rec.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, "invalid_request_error", resp.Error.Type)
+	assert.NotNil(t, resp.Error.Code)
+	assert.Equal(t, "DENIAL_REASON_PROJECT_NOT_FOUND", *resp.Error.Code)
+}
+
+func TestWorkerHTTPMiddleware(t *testing.T) {
+	interceptor := &WorkerInterceptor{
+		client: &fakeInternalServerClient{
+			t: t,
+		},
+	}
+
+	var clusterInfo *ClusterInfo
+	next := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		clusterInfo, _ = ExtractClusterInfoFromContext(req.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+	h := interceptor.HTTPMiddleware("/healthz")(next)
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/status", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.NotNil(t, clusterInfo)
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/v1/status", nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	var resp errorResponse
+	err := json.Unmarshal(rec.Body.Bytes(), &resp)
+	assert.NoError(t, err)
+	assert.Equal(t, "missing authorization", resp.Error.Message)
+}
@@ -0,0 +1,66 @@
+package auth
+
+import (
+	"fmt"
+	"strings"
+
+	rbacv1 "github.com/llmariner/rbac-manager/api/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// requiredScope is the resource and the capability declared with the required_scope option of an RPC.
+type requiredScope struct {
+	resource   string
+	capability string
+}
+
+// parseRequiredScope splits a scope into the resource and the capability. The capability
+// is the last segment of the scope.
+func parseRequiredScope(s string) (requiredScope, error) {
+	i := strings.LastIndex(s, ".")
+	if i <= 0 || i == len(s)-1 {
+		return requiredScope{}, fmt.Errorf("scope %q does not have the <resource>.<capability> form", s)
+	}
+	return requiredScope{
+		resource:   s[:i],
+		capability: s[i+1:],
+	}, nil
+}
+
+// requiredScopesFromServices returns a map from the full method name ("/<service>/<method>")
+// of each RPC of the services to its required scope. It returns an error if any RPC does not
+// have the required_scope option.
+func requiredScopesFromServices(files *protoregistry.Files, services []string) (map[string]requiredScope, error) {
+	scopes := map[string]requiredScope{}
+	for _, name := range services {
+		d, err := files.FindDescriptorByName(protoreflect.FullName(name))
+		if err != nil {
+			return nil, fmt.Errorf("find service %q: %s", name, err)
+		}
+		sd, ok := d.(protoreflect.ServiceDescriptor)
+		if !ok {
+			return nil, fmt.Errorf("%q is not a service", name)
+		}
+
+		methods := sd.Methods()
+		for i := 0; i < methods.Len(); i++ {
+			md := methods.Get(i)
+			var s string
+			if opts, ok := md.Options().(*descriptorpb.MethodOptions); ok && opts != nil {
+				s = proto.GetExtension(opts, rbacv1.E_RequiredScope).(string)
+			}
+			if s == "" {
+				return nil, fmt.Errorf("method %q does not have the required_scope option", md.FullName())
+			}
+			rs, err := parseRequiredScope(s)
+			if err != nil {
+				return nil, fmt.Errorf("method %q: %s", md.FullName(), err)
+			}
+			scopes[fmt.Sprintf("/%s/%s", sd.FullName(), md.Name())] = rs
+		}
+	}
+	return scopes, nil
+}
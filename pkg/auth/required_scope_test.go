@@ -0,0 +1,162 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	rbacv1 "github.com/llmariner/rbac-manager/api/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func TestParseRequiredScope(t *testing.T) {
+	tcs := []struct {
+		scope   string
+		want    requiredScope
+		wantErr bool
+	}{
+		{
+			scope: "api.files.write",
+			want:  requiredScope{resource: "api.files", capability: "write"},
+		},
+		{
+			scope:   "api",
+			wantErr: true,
+		},
+		{
+			scope:   "api.files.",
+			wantErr: true,
+		},
+		{
+			scope:   ".write",
+			wantErr: true,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.scope, func(t *testing.T) {
+			got, err := parseRequiredScope(tc.scope)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestRequiredScopesFromServices(t *testing.T) {
+	files := newTestFiles(t, map[string]string{
+		"GetFile":    "api.files.read",
+		"DeleteFile": "api.files.write",
+	})
+	got, err := requiredScopesFromServices(files, []string{"test.v1.FilesService"})
+	assert.NoError(t, err)
+	want := map[string]requiredScope{
+		"/test.v1.FilesService/GetFile":    {resource: "api.files", capability: "read"},
+		"/test.v1.FilesService/DeleteFile": {resource: "api.files", capability: "write"},
+	}
+	assert.Equal(t, want, got)
+
+	// An RPC without the option.
+	files = newTestFiles(t, map[string]string{
+		"GetFile":    "api.files.read",
+		"DeleteFile": "",
+	})
+	_, err = requiredScopesFromServices(files, []string{"test.v1.FilesService"})
+	assert.Error(t, err)
+
+	// An unknown service.
+	_, err = requiredScopesFromServices(files, []string{"test.v1.UnknownService"})
+	assert.Error(t, err)
+}
+
+func TestUnary_RequiredScope(t *testing.T) {
+	files := newTestFiles(t, map[string]string{
+		"GetFile":    "api.files.read",
+		"DeleteFile": "api.files.write",
+	})
+	scopes, err := requiredScopesFromServices(files, []string{"test.v1.FilesService"})
+	assert.NoError(t, err)
+
+	client := &fakeInternalServerClient{
+		t:              t,
+		wantResource:   "api.files",
+		wantCapability: "write",
+	}
+	interceptor := &Interceptor{
+		client:         client,
+		requiredScopes: scopes,
+	}
+
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer token"))
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) { return "ok", nil }
+
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.v1.FilesService/DeleteFile"}
+	_, err = interceptor.Unary()(ctx, nil, info, handler)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, client.counter)
+
+	// A method without a required scope is denied.
+	info = &grpc.UnaryServerInfo{FullMethod: "/test.v1.OtherService/DeleteFile"}
+	_, err = interceptor.Unary()(ctx, nil, info, handler)
+	assert.Error(t, err)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	assert.Equal(t, 1, client.counter)
+
+	// HTTP requests fail without a resource as only the scoped services are configured.
+	req := &http.Request{
+		Method: http.MethodGet,
+		Header: http.Header{"Authorization": []string{"Bearer token"}},
+		URL:    &url.URL{},
+	}
+	statusCode, _, err := interceptor.InterceptHTTPRequest(req)
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusInternalServerError, statusCode)
+	assert.Equal(t, 1, client.counter)
+}
+
+// newTestFiles returns a registry that has the "test.v1.FilesService" service. The service has
+// an RPC for each key of scopes, annotated with its value. An empty value means no annotation.
+func newTestFiles(t *testing.T, scopes map[string]string) *protoregistry.Files {
+	empty := "." + string((&emptypb.Empty{}).ProtoReflect().Descriptor().FullName())
+	sd := &descriptorpb.ServiceDescriptorProto{
+		Name: proto.String("FilesService"),
+	}
+	for name, scope := range scopes {
+		md := &descriptorpb.MethodDescriptorProto{
+			Name:       proto.String(name),
+			InputType:  proto.String(empty),
+			OutputType: proto.String(empty),
+		}
+		if scope != "" {
+			md.Options = &descriptorpb.MethodOptions{}
+			proto.SetExtension(md.Options, rbacv1.E_RequiredScope, scope)
+		}
+		sd.Method = append(sd.Method, md)
+	}
+	fd := &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("test/v1/files.proto"),
+		Package:    proto.String("test.v1"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{emptypb.File_google_protobuf_empty_proto.Path()},
+		Service:    []*descriptorpb.ServiceDescriptorProto{sd},
+	}
+	f, err := protodesc.NewFile(fd, protoregistry.GlobalFiles)
+	assert.NoError(t, err)
+
+	files := &protoregistry.Files{}
+	err = files.RegisterFile(f)
+	assert.NoError(t, err)
+	return files
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bytes"
 	"context"
 	"fmt"
 	"log"
@@ -15,6 +16,7 @@ import (
 	"github.com/llmariner/rbac-manager/server/internal/cache"
 	"github.com/llmariner/rbac-manager/server/internal/config"
 	"github.com/llmariner/rbac-manager/server/internal/monitoring"
+	"github.com/llmariner/rbac-manager/server/internal/readiness"
 	"github.com/llmariner/rbac-manager/server/internal/server"
 	"github.com/llmariner/rbac-manager/server/internal/token"
 	uv1 "github.com/llmariner/user-manager/api/v1"
@@ -60,19 +62,20 @@ func run(ctx context.Context, c *config.Config) error {
 
 	log.Info("Starting internal-grpc server...", "port", c.InternalGRPCPort)
 
-	conn, err := grpc.NewClient(
-		c.CacheConfig.UserManagerServerInternalAddr,
+	opts := []grpc.DialOption{
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+	}
+	if s := c.CacheConfig.MaxRecvMsgSize; s > 0 {
+		opts = append(opts, grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(s)))
+	}
+
+	conn, err := grpc.NewClient(c.CacheConfig.UserManagerServerInternalAddr, opts...)
 	if err != nil {
 		return err
 	}
 	uClient := uv1.NewUsersInternalServiceClient(conn)
 
-	conn, err = grpc.NewClient(
-		c.CacheConfig.ClusterManagerServerInternalAddr,
-		grpc.WithTransportCredentials(insecure.NewCredentials()),
-	)
+	conn, err = grpc.NewClient(c.CacheConfig.ClusterManagerServerInternalAddr, opts...)
 	if err != nil {
 		return err
 	}
@@ -81,7 +84,19 @@ func run(ctx context.Context, c *config.Config) error {
 	sigCh := make(chan os.Signal, 1)
 	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
 
-	cstore := cache.NewStore(uClient, cClient)
+	sopts := cache.StoreOpts{StrictSync: c.CacheConfig.StrictSync}
+	if sc := c.CacheConfig.Snapshot; sc.Path != "" {
+		key, err := os.ReadFile(sc.EncryptionKeyFile)
+		if err != nil {
+			return fmt.Errorf("read snapshot encryption key: %s", err)
+		}
+		sopts.Snapshot = &cache.SnapshotOpts{
+			Path:          sc.Path,
+			EncryptionKey: bytes.TrimSpace(key),
+			MaxAge:        sc.MaxAge,
+		}
+	}
+	cstore := cache.NewStore(uClient, cClient, sopts)
 	errCh := make(chan error)
 	go func() {
 		errCh <- cstore.Sync(ctx, c.CacheConfig.SyncInterval)
@@ -94,17 +109,30 @@ func run(ctx context.Context, c *config.Config) error {
 
 	// We could wait for the cache to be populated before starting the server, but
 	// we intentionally avoid that here to avoid hard dependency to user-manager-server.
+	// If the snapshot is enabled, the initial sync is also satisfied by loading the snapshot.
 	// TODO(kenji): Consider revisit this.
 
 	ta, err := token.NewValidator(ctx, c.JWKSURL, token.ValidatorOpts{Refresh: 1 * time.Hour})
 	if err != nil {
 		return err
 	}
-	srv := server.New(ta, cstore, c.RoleScopesMap)
+	roleScopes, err := c.RoleScopes()
+	if err != nil {
+		return err
+	}
+	srv, err := server.New(ta, cstore, roleScopes)
+	if err != nil {
+		return err
+	}
 	go func() {
 		errCh <- srv.Run(ctx, c.InternalGRPCPort)
 	}()
 
+	rc := readiness.NewChecker(cstore, c.CacheConfig.StalenessThreshold, logger)
+	go func() {
+		errCh <- rc.Run(ctx, monitoringRunnerInterval, srv)
+	}()
+
 	m := monitoring.NewMetricsMonitor(cstore, logger)
 	go func() {
 		errCh <- m.Run(ctx, monitoringRunnerInterval)
@@ -117,6 +145,8 @@ func run(ctx context.Context, c *config.Config) error {
 		log.Info("Starting metrics server...", "port", c.MonitoringPort)
 		mux := http.NewServeMux()
 		mux.Handle("/metrics", promhttp.Handler())
+		mux.HandleFunc("/healthz", rc.ServeHealthz)
+		mux.HandleFunc("/readyz", rc.ServeReadyz)
 		errCh <- http.ListenAndServe(fmt.Sprintf(":%d", c.MonitoringPort), mux)
 		log.Info("Stopped metrics server")
 	}()
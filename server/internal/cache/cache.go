@@ -2,6 +2,9 @@ package cache
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
 	"fmt"
 	"log"
 	"sync"
@@ -13,6 +16,14 @@ import (
 )
 
 // K represents an API key.
+//
+// TODO: Restrict API keys to their own scope patterns. user-manager does not return the patterns
+// of API keys yet. Once it does, compile them into a scope.Matcher per key in updateCache so that
+// a malformed pattern is reported once per sync instead of denying every request.
+//
+// TODO: Reject API keys outside of their validity period. user-manager does not return the
+// validity period of API keys yet. Once it does, check it in Authorize on every request so that
+// an expiry takes effect without waiting for the next sync.
 type K struct {
 	KeyID string
 
@@ -86,16 +97,32 @@ type clusterInfoLister interface {
 	ListInternalClusters(ctx context.Context, in *cv1.ListInternalClustersRequest, opts ...grpc.CallOption) (*cv1.ListInternalClustersResponse, error)
 }
 
+// StoreOpts is the options for a Store.
+type StoreOpts struct {
+	// StrictSync makes a sync fail if any record is inconsistent. Otherwise, inconsistent
+	// records are skipped and the rest of the records are committed.
+	StrictSync bool
+
+	// Snapshot enables the on-disk snapshot if set. A snapshot is written after each successful
+	// sync, and it is loaded at startup if the initial sync fails.
+	Snapshot *SnapshotOpts
+}
+
 // NewStore creates a new cache store.
 func NewStore(
 	userInfoLister userInfoLister,
 	clusterInfoLister clusterInfoLister,
+	opts StoreOpts,
 ) *Store {
 	return &Store{
 		userInfoLister:    userInfoLister,
 		clusterInfoLister: clusterInfoLister,
 
-		apiKeysBySecret: map[string]*K{},
+		strictSync:   opts.StrictSync,
+		snapshotOpts: opts.Snapshot,
+
+		secretHashKey:       newSecretHashKey(),
+		apiKeysBySecretHash: map[string]*K{},
 
 		clustersByRegistrationKey: map[string]*C{},
 		clustersByTenantID:        map[string][]C{},
@@ -107,6 +134,9 @@ func NewStore(
 		projectsByOrganizationID: map[string][]P{},
 		projectsByUserID:         map[string][]PU{},
 
+		invalidatedAPIKeyIDs: map[string]time.Time{},
+		invalidatedUserIDs:   map[string]time.Time{},
+
 		initialSync: make(chan struct{}),
 	}
 }
@@ -116,8 +146,15 @@ type Store struct {
 	userInfoLister    userInfoLister
 	clusterInfoLister clusterInfoLister
 
-	// apiKeysBySecret is a set of API keys, keyed by its secret.
-	apiKeysBySecret map[string]*K
+	strictSync   bool
+	snapshotOpts *SnapshotOpts
+
+	// secretHashKey is the key used to hash API key secrets. It is generated per process
+	// unless it is restored from a snapshot, which persists it together with the hashes.
+	secretHashKey []byte
+	// apiKeysBySecretHash is a set of API keys, keyed by the hash of its secret (see hashSecret).
+	// Plaintext secrets are not kept in the cache so that they are not exposed in heap dumps.
+	apiKeysBySecretHash map[string]*K
 
 	// clustersByRegistrationKey is a set of clusters, keyed by its registration key.
 	clustersByRegistrationKey map[string]*C
@@ -140,7 +177,15 @@ type Store struct {
 	// usersByID is a set of users, keyed by its ID.
 	usersByID map[string]*U
 
+	// invalidatedAPIKeyIDs and invalidatedUserIDs are tombstones of the revoked API keys and users,
+	// keyed by their IDs. The values are the times of the invalidation. A tombstone is kept until
+	// a full sync that started after the invalidation completes.
+	invalidatedAPIKeyIDs map[string]time.Time
+	invalidatedUserIDs   map[string]time.Time
+
 	lastSuccessfulSyncTime time.Time
+	// lastSkippedRecordCount is the number of inconsistent records skipped in the last successful sync.
+	lastSkippedRecordCount int
 
 	mu sync.RWMutex
 
@@ -153,13 +198,33 @@ func (c *Store) GetAPIKeyBySecret(secret string) (*K, bool) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
-	k, ok := c.apiKeysBySecret[secret]
+	// The map lookup is not constant time, but it only compares HMAC digests, which do not
+	// reveal anything about the secrets without secretHashKey.
+	k, ok := c.apiKeysBySecretHash[c.hashSecret(secret)]
 	if !ok {
 		return nil, false
 	}
+	if _, ok := c.invalidatedAPIKeyIDs[k.KeyID]; ok {
+		return nil, false
+	}
+	if _, ok := c.invalidatedUserIDs[k.UserID]; ok {
+		return nil, false
+	}
 	return k, true
 }
 
+// GetAPIKeyIDs returns the IDs of all the API keys in the cache.
+func (c *Store) GetAPIKeyIDs() map[string]bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	ids := make(map[string]bool, len(c.apiKeysBySecretHash))
+	for _, k := range c.apiKeysBySecretHash {
+		ids[k.KeyID] = true
+	}
+	return ids
+}
+
 // GetClusterByRegistrationKey returns a cluster by its registration key.
 func (c *Store) GetClusterByRegistrationKey(key string) (*C, bool) {
 	c.mu.RLock()
@@ -222,10 +287,27 @@ func (c *Store) GetProjectsByUserID(userID string) []PU {
 func (c *Store) GetUserByID(userID string) (*U, bool) {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
+	if _, ok := c.invalidatedUserIDs[userID]; ok {
+		return nil, false
+	}
 	u, ok := c.usersByID[userID]
 	return u, ok
 }
 
+// InvalidateAPIKey revokes an API key until the next full sync.
+func (c *Store) InvalidateAPIKey(keyID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.invalidatedAPIKeyIDs[keyID] = time.Now()
+}
+
+// InvalidateUser revokes a user and the user's API keys until the next full sync.
+func (c *Store) InvalidateUser(userID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.invalidatedUserIDs[userID] = time.Now()
+}
+
 // GetLastSuccessfulSyncTime returns the last successful sync time.
 func (c *Store) GetLastSuccessfulSyncTime() time.Time {
 	c.mu.RLock()
@@ -233,12 +315,30 @@ func (c *Store) GetLastSuccessfulSyncTime() time.Time {
 	return c.lastSuccessfulSyncTime
 }
 
+// GetLastSkippedRecordCount returns the number of inconsistent records skipped in the last successful sync.
+func (c *Store) GetLastSkippedRecordCount() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.lastSkippedRecordCount
+}
+
 // Sync synchronizes the cache.
+//
+// TODO: Add a watch mode that applies incremental changes to the indexes and keeps the periodic
+// full resync as a fallback. This requires user-manager and cluster-manager to expose watch (or
+// change feed) RPCs, which they do not have yet.
 func (c *Store) Sync(ctx context.Context, interval time.Duration) error {
-	if err := c.updateCache(ctx); err != nil {
-		// Gracefully ignore the error.
-		// TODO(kenji): Make the pod unready.
+	if err := c.syncOnce(ctx); err != nil {
+		// Gracefully ignore the error. The pod becomes unready if the cache gets stale.
 		log.Printf("Failed to update the cache: %s. Ignoring.", err)
+
+		if c.snapshotOpts != nil {
+			if err := c.loadSnapshot(time.Now()); err != nil {
+				log.Printf("Failed to load the cache snapshot: %s.", err)
+			} else {
+				log.Printf("Loaded the cache snapshot from %s.", c.snapshotOpts.Path)
+			}
+		}
 	}
 
 	ticker := time.NewTicker(interval)
@@ -247,16 +347,49 @@ func (c *Store) Sync(ctx context.Context, interval time.Duration) error {
 		case <-ctx.Done():
 			return ctx.Err()
 		case <-ticker.C:
-			if err := c.updateCache(ctx); err != nil {
-				// Gracefully ignore the error.
-				// TODO(kenji): Make the pod unready.
+			if err := c.syncOnce(ctx); err != nil {
+				// Gracefully ignore the error. The pod becomes unready if the cache gets stale.
 				log.Printf("Failed to update the cache: %s. Ignoring.", err)
 			}
 		}
 	}
 }
 
+// syncOnce updates the cache and writes the snapshot if enabled.
+func (c *Store) syncOnce(ctx context.Context) error {
+	if err := c.updateCache(ctx); err != nil {
+		return err
+	}
+	if c.snapshotOpts != nil {
+		if err := c.writeSnapshot(); err != nil {
+			// The snapshot is only used as a fallback, so do not fail the sync.
+			log.Printf("Failed to write the cache snapshot: %s.", err)
+		}
+	}
+	return nil
+}
+
+// updateCache lists all the entries and swaps the indexes once every list call has succeeded.
+//
+// TODO: Page through the list results once the List RPCs of user-manager accept page tokens.
+// Their requests have no fields yet, so every sync fetches all records in one response, and
+// large tenants need to raise maxRecvMsgSize in the cache config.
 func (c *Store) updateCache(ctx context.Context) error {
+	startTime := time.Now()
+
+	var skipped int
+	// skip returns an error in the strict mode. Otherwise it logs the inconsistency, and the caller
+	// skips the record.
+	skip := func(format string, args ...any) error {
+		err := fmt.Errorf(format, args...)
+		if c.strictSync {
+			return err
+		}
+		log.Printf("Skipping an inconsistent record: %s", err)
+		skipped++
+		return nil
+	}
+
 	resp, err := c.userInfoLister.ListInternalAPIKeys(ctx, &uv1.ListInternalAPIKeysRequest{})
 	if err != nil {
 		return err
@@ -264,7 +397,7 @@ func (c *Store) updateCache(ctx context.Context) error {
 
 	m := map[string]*K{}
 	for _, apiKey := range resp.ApiKeys {
-		m[apiKey.ApiKey.Secret] = &K{
+		m[c.hashSecret(apiKey.ApiKey.Secret)] = &K{
 			KeyID: apiKey.ApiKey.Id,
 
 			UserID:         apiKey.ApiKey.User.Id,
@@ -352,7 +485,10 @@ func (c *Store) updateCache(ctx context.Context) error {
 	for _, user := range projectUsers.Users {
 		p, ok := projectsByID[user.ProjectId]
 		if !ok {
-			return fmt.Errorf("project %s not found for user %s", user.ProjectId, user.UserId)
+			if err := skip("project %s not found for user %s", user.ProjectId, user.UserId); err != nil {
+				return err
+			}
+			continue
 		}
 
 		projectsByUserID[user.UserId] = append(projectsByUserID[user.UserId], PU{
@@ -363,15 +499,24 @@ func (c *Store) updateCache(ctx context.Context) error {
 	}
 
 	usersByID := map[string]*U{}
+	// conflictingUserIDs is the set of users that have multiple tenant IDs. They are removed
+	// as we cannot tell which tenant they belong to.
+	conflictingUserIDs := map[string]bool{}
 	for _, user := range orgUsers.Users {
 		o, ok := orgsByID[user.OrganizationId]
 		if !ok {
-			return fmt.Errorf("organization %s not found for user %s", user.OrganizationId, user.UserId)
+			if err := skip("organization %s not found for user %s", user.OrganizationId, user.UserId); err != nil {
+				return err
+			}
+			continue
 		}
 
 		if existing, ok := usersByID[user.UserId]; ok {
-			if existing.TenantID != o.TenantID {
-				return fmt.Errorf("user %s has multiple tenant IDs", user.UserId)
+			if existing.TenantID != o.TenantID && !conflictingUserIDs[user.UserId] {
+				if err := skip("user %s has multiple tenant IDs", user.UserId); err != nil {
+					return err
+				}
+				conflictingUserIDs[user.UserId] = true
 			}
 			continue
 		}
@@ -383,10 +528,14 @@ func (c *Store) updateCache(ctx context.Context) error {
 		}
 	}
 
+	for id := range conflictingUserIDs {
+		delete(usersByID, id)
+	}
+
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	c.apiKeysBySecret = m
+	c.apiKeysBySecretHash = m
 
 	c.clustersByRegistrationKey = cs
 	c.clustersByTenantID = csByTenantID
@@ -400,7 +549,12 @@ func (c *Store) updateCache(ctx context.Context) error {
 
 	c.usersByID = usersByID
 
+	// The synced data reflects the invalidations made before the sync started.
+	deleteTombstonesBefore(c.invalidatedAPIKeyIDs, startTime)
+	deleteTombstonesBefore(c.invalidatedUserIDs, startTime)
+
 	c.lastSuccessfulSyncTime = time.Now()
+	c.lastSkippedRecordCount = skipped
 
 	if !c.synced {
 		close(c.initialSync)
@@ -419,3 +573,27 @@ func (c *Store) WaitForSync(ctx context.Context) error {
 		return ctx.Err()
 	}
 }
+
+func deleteTombstonesBefore(tombstones map[string]time.Time, t time.Time) {
+	for id, invalidatedAt := range tombstones {
+		if invalidatedAt.Before(t) {
+			delete(tombstones, id)
+		}
+	}
+}
+
+// hashSecret returns the HMAC-SHA256 digest of the API key secret.
+func (c *Store) hashSecret(secret string) string {
+	h := hmac.New(sha256.New, c.secretHashKey)
+	_, _ = h.Write([]byte(secret))
+	return string(h.Sum(nil))
+}
+
+func newSecretHashKey() []byte {
+	key := make([]byte, sha256.Size)
+	if _, err := rand.Read(key); err != nil {
+		// crypto/rand is not expected to fail.
+		panic(fmt.Sprintf("failed to generate a secret hash key: %s", err))
+	}
+	return key
+}
@@ -10,6 +10,16 @@ import (
 	"google.golang.org/grpc"
 )
 
+func TestHashSecret(t *testing.T) {
+	c0 := NewStore(nil, nil, StoreOpts{})
+	c1 := NewStore(nil, nil, StoreOpts{})
+
+	assert.Equal(t, c0.hashSecret("s0"), c0.hashSecret("s0"))
+	assert.NotEqual(t, c0.hashSecret("s0"), c0.hashSecret("s1"))
+	// Each store uses its own key.
+	assert.NotEqual(t, c0.hashSecret("s0"), c1.hashSecret("s0"))
+}
+
 func TestCache(t *testing.T) {
 	ul := &fakeUserInfoLister{
 		apikeys: &uv1.ListInternalAPIKeysResponse{
@@ -125,7 +135,7 @@ func TestCache(t *testing.T) {
 		},
 	}
 
-	c := NewStore(ul, cl)
+	c := NewStore(ul, cl, StoreOpts{})
 	ctx := context.Background()
 	go func() {
 		err := c.updateCache(ctx)
@@ -169,7 +179,12 @@ func TestCache(t *testing.T) {
 		assert.Equal(t, v.KeyID, got.KeyID)
 		assert.Equal(t, v.OrganizationRole, got.OrganizationRole)
 		assert.Equal(t, v.ProjectRole, got.ProjectRole)
+
+		// Plaintext secrets should not be kept in the cache.
+		_, ok = c.apiKeysBySecretHash[k]
+		assert.False(t, ok)
 	}
+	assert.Equal(t, map[string]bool{"id0": true, "id1": true}, c.GetAPIKeyIDs())
 
 	wantClusters := map[string]*C{
 		"rkey0": {
@@ -309,6 +324,138 @@ func TestCache(t *testing.T) {
 	}
 }
 
+func TestInvalidate(t *testing.T) {
+	ul := &fakeUserInfoLister{
+		apikeys: &uv1.ListInternalAPIKeysResponse{
+			ApiKeys: []*uv1.InternalAPIKey{
+				{
+					ApiKey: &uv1.APIKey{
+						Id:           "k0",
+						Secret:       "s0",
+						User:         &uv1.User{Id: "u0"},
+						Organization: &uv1.Organization{Id: "o0"},
+						Project:      &uv1.Project{Id: "p0"},
+					},
+				},
+				{
+					ApiKey: &uv1.APIKey{
+						Id:           "k1",
+						Secret:       "s1",
+						User:         &uv1.User{Id: "u1"},
+						Organization: &uv1.Organization{Id: "o0"},
+						Project:      &uv1.Project{Id: "p0"},
+					},
+				},
+			},
+		},
+		orgs: &uv1.ListInternalOrganizationsResponse{
+			Organizations: []*uv1.InternalOrganization{
+				{Organization: &uv1.Organization{Id: "o0"}},
+			},
+		},
+		orgusers: &uv1.ListOrganizationUsersResponse{
+			Users: []*uv1.OrganizationUser{
+				{UserId: "u0", OrganizationId: "o0"},
+				{UserId: "u1", OrganizationId: "o0"},
+			},
+		},
+		projects:     &uv1.ListProjectsResponse{},
+		projectusers: &uv1.ListProjectUsersResponse{},
+	}
+	cl := &fakeClusterInfoLister{
+		clusters: &cv1.ListInternalClustersResponse{},
+	}
+	c := NewStore(ul, cl, StoreOpts{})
+	ctx := context.Background()
+	err := c.updateCache(ctx)
+	assert.NoError(t, err)
+
+	c.InvalidateAPIKey("k0")
+	c.InvalidateUser("u1")
+
+	_, ok := c.GetAPIKeyBySecret("s0")
+	assert.False(t, ok)
+	// The API key of the invalidated user is also revoked.
+	_, ok = c.GetAPIKeyBySecret("s1")
+	assert.False(t, ok)
+	_, ok = c.GetUserByID("u1")
+	assert.False(t, ok)
+	_, ok = c.GetUserByID("u0")
+	assert.True(t, ok)
+
+	// A full sync that started after the invalidation removes the tombstones.
+	err = c.updateCache(ctx)
+	assert.NoError(t, err)
+	_, ok = c.GetAPIKeyBySecret("s0")
+	assert.True(t, ok)
+	_, ok = c.GetUserByID("u1")
+	assert.True(t, ok)
+}
+
+func TestUpdateCache_InconsistentRecords(t *testing.T) {
+	newListers := func() (*fakeUserInfoLister, *fakeClusterInfoLister) {
+		ul := &fakeUserInfoLister{
+			apikeys: &uv1.ListInternalAPIKeysResponse{},
+			orgs: &uv1.ListInternalOrganizationsResponse{
+				Organizations: []*uv1.InternalOrganization{
+					{Organization: &uv1.Organization{Id: "o0"}, TenantId: "t0"},
+					{Organization: &uv1.Organization{Id: "o1"}, TenantId: "t1"},
+				},
+			},
+			orgusers: &uv1.ListOrganizationUsersResponse{
+				Users: []*uv1.OrganizationUser{
+					{UserId: "u0", OrganizationId: "o0"},
+					// Unknown organization.
+					{UserId: "u1", OrganizationId: "o2"},
+					{UserId: "u1", OrganizationId: "o0"},
+					// Multiple tenant IDs.
+					{UserId: "u2", OrganizationId: "o0"},
+					{UserId: "u2", OrganizationId: "o1"},
+				},
+			},
+			projects: &uv1.ListProjectsResponse{
+				Projects: []*uv1.Project{
+					{Id: "p0", OrganizationId: "o0"},
+				},
+			},
+			projectusers: &uv1.ListProjectUsersResponse{
+				Users: []*uv1.ProjectUser{
+					{UserId: "u0", ProjectId: "p0"},
+					// Unknown project.
+					{UserId: "u0", ProjectId: "p1"},
+				},
+			},
+		}
+		cl := &fakeClusterInfoLister{
+			clusters: &cv1.ListInternalClustersResponse{},
+		}
+		return ul, cl
+	}
+
+	t.Run("non-strict", func(t *testing.T) {
+		ul, cl := newListers()
+		c := NewStore(ul, cl, StoreOpts{})
+		err := c.updateCache(context.Background())
+		assert.NoError(t, err)
+
+		assert.Equal(t, 3, c.GetLastSkippedRecordCount())
+		_, ok := c.GetUserByID("u0")
+		assert.True(t, ok)
+		_, ok = c.GetUserByID("u1")
+		assert.True(t, ok)
+		_, ok = c.GetUserByID("u2")
+		assert.False(t, ok)
+		assert.Len(t, c.GetProjectsByUserID("u0"), 1)
+	})
+
+	t.Run("strict", func(t *testing.T) {
+		ul, cl := newListers()
+		c := NewStore(ul, cl, StoreOpts{StrictSync: true})
+		err := c.updateCache(context.Background())
+		assert.Error(t, err)
+	})
+}
+
 type fakeUserInfoLister struct {
 	apikeys      *uv1.ListInternalAPIKeysResponse
 	orgs         *uv1.ListInternalOrganizationsResponse
@@ -0,0 +1,189 @@
+package cache
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/gob"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// SnapshotOpts is the options for the on-disk snapshot of a Store.
+type SnapshotOpts struct {
+	// Path is the path of the snapshot file.
+	Path string
+	// EncryptionKey is the key used to encrypt the snapshot. It can be of any length as an
+	// AES-256 key is derived from it.
+	EncryptionKey []byte
+	// MaxAge is the maximum age of a snapshot that can be loaded.
+	MaxAge time.Duration
+}
+
+// snapshot is the content of the snapshot file.
+type snapshot struct {
+	CreatedAt time.Time
+
+	// SecretHashKey is persisted so that APIKeysBySecretHash can be used after a restart.
+	SecretHashKey       []byte
+	APIKeysBySecretHash map[string]*K
+
+	ClustersByRegistrationKey map[string]*C
+	ClustersByTenantID        map[string][]C
+
+	OrgsByID     map[string]*O
+	OrgsByUserID map[string][]OU
+
+	ProjectsByID             map[string]*P
+	ProjectsByOrganizationID map[string][]P
+	ProjectsByUserID         map[string][]PU
+
+	UsersByID map[string]*U
+}
+
+// writeSnapshot writes the current content of the store to the snapshot file.
+func (c *Store) writeSnapshot() error {
+	c.mu.RLock()
+	s := &snapshot{
+		CreatedAt: c.lastSuccessfulSyncTime,
+
+		SecretHashKey:       c.secretHashKey,
+		APIKeysBySecretHash: c.apiKeysBySecretHash,
+
+		ClustersByRegistrationKey: c.clustersByRegistrationKey,
+		ClustersByTenantID:        c.clustersByTenantID,
+
+		OrgsByID:     c.orgsByID,
+		OrgsByUserID: c.orgsByUserID,
+
+		ProjectsByID:             c.projectsByID,
+		ProjectsByOrganizationID: c.projectsByOrganizationID,
+		ProjectsByUserID:         c.projectsByUserID,
+
+		UsersByID: c.usersByID,
+	}
+	var buf bytes.Buffer
+	err := gob.NewEncoder(&buf).Encode(s)
+	c.mu.RUnlock()
+	if err != nil {
+		return fmt.Errorf("encode: %s", err)
+	}
+
+	b, err := encrypt(c.snapshotOpts.EncryptionKey, buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("encrypt: %s", err)
+	}
+
+	// Write to a temporary file and rename it so that a crash does not leave a partial snapshot.
+	path := c.snapshotOpts.Path
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(f.Name()) }()
+	if _, err := f.Write(b); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), path)
+}
+
+// loadSnapshot loads the snapshot file into the store. It returns an error if the snapshot
+// is older than the max age.
+func (c *Store) loadSnapshot(now time.Time) error {
+	b, err := os.ReadFile(c.snapshotOpts.Path)
+	if err != nil {
+		return err
+	}
+	b, err = decrypt(c.snapshotOpts.EncryptionKey, b)
+	if err != nil {
+		return fmt.Errorf("decrypt: %s", err)
+	}
+	var s snapshot
+	if err := gob.NewDecoder(bytes.NewReader(b)).Decode(&s); err != nil {
+		return fmt.Errorf("decode: %s", err)
+	}
+	if age := now.Sub(s.CreatedAt); age > c.snapshotOpts.MaxAge {
+		return fmt.Errorf("snapshot is too old (created at %s)", s.CreatedAt.Format(time.RFC3339))
+	}
+
+	// Restore the pointers from the projects in PU to the projects in projectsByID.
+	for _, pus := range s.ProjectsByUserID {
+		for i, pu := range pus {
+			if pu.Project == nil {
+				continue
+			}
+			if p, ok := s.ProjectsByID[pu.Project.ID]; ok {
+				pus[i].Project = p
+			}
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.secretHashKey = s.SecretHashKey
+	c.apiKeysBySecretHash = s.APIKeysBySecretHash
+
+	c.clustersByRegistrationKey = s.ClustersByRegistrationKey
+	c.clustersByTenantID = s.ClustersByTenantID
+
+	c.orgsByID = s.OrgsByID
+	c.orgsByUserID = s.OrgsByUserID
+
+	c.projectsByID = s.ProjectsByID
+	c.projectsByOrganizationID = s.ProjectsByOrganizationID
+	c.projectsByUserID = s.ProjectsByUserID
+
+	c.usersByID = s.UsersByID
+
+	// Keep the sync time of the snapshot so that the staleness of the cache is reported correctly.
+	c.lastSuccessfulSyncTime = s.CreatedAt
+
+	if !c.synced {
+		close(c.initialSync)
+	}
+	c.synced = true
+
+	return nil
+}
+
+func encrypt(key, plaintext []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func decrypt(key, ciphertext []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, fmt.Errorf("ciphertext is too short")
+	}
+	nonce, ciphertext := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, nil)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	k := sha256.Sum256(key)
+	block, err := aes.NewCipher(k[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,116 @@
+package cache
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	cv1 "github.com/llmariner/cluster-manager/api/v1"
+	uv1 "github.com/llmariner/user-manager/api/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshot(t *testing.T) {
+	ul := &fakeUserInfoLister{
+		apikeys: &uv1.ListInternalAPIKeysResponse{
+			ApiKeys: []*uv1.InternalAPIKey{
+				{
+					ApiKey: &uv1.APIKey{
+						Id:           "k0",
+						Secret:       "s0",
+						User:         &uv1.User{Id: "u0"},
+						Organization: &uv1.Organization{Id: "o0"},
+						Project:      &uv1.Project{Id: "p0"},
+					},
+					TenantId: "t0",
+				},
+			},
+		},
+		orgs: &uv1.ListInternalOrganizationsResponse{
+			Organizations: []*uv1.InternalOrganization{
+				{Organization: &uv1.Organization{Id: "o0"}, TenantId: "t0"},
+			},
+		},
+		orgusers: &uv1.ListOrganizationUsersResponse{
+			Users: []*uv1.OrganizationUser{
+				{UserId: "u0", OrganizationId: "o0"},
+			},
+		},
+		projects: &uv1.ListProjectsResponse{
+			Projects: []*uv1.Project{
+				{Id: "p0", OrganizationId: "o0"},
+			},
+		},
+		projectusers: &uv1.ListProjectUsersResponse{
+			Users: []*uv1.ProjectUser{
+				{UserId: "u0", ProjectId: "p0"},
+			},
+		},
+	}
+	cl := &fakeClusterInfoLister{
+		clusters: &cv1.ListInternalClustersResponse{
+			Clusters: []*cv1.InternalCluster{
+				{
+					Cluster: &cv1.Cluster{
+						Id:              "c0",
+						RegistrationKey: "rkey0",
+					},
+					TenantId: "t0",
+				},
+			},
+		},
+	}
+
+	path := filepath.Join(t.TempDir(), "snapshot")
+	opts := &SnapshotOpts{
+		Path:          path,
+		EncryptionKey: []byte("key"),
+		MaxAge:        time.Hour,
+	}
+	c := NewStore(ul, cl, StoreOpts{Snapshot: opts})
+	err := c.syncOnce(context.Background())
+	assert.NoError(t, err)
+	syncTime := c.GetLastSuccessfulSyncTime()
+
+	t.Run("load", func(t *testing.T) {
+		c := NewStore(nil, nil, StoreOpts{Snapshot: opts})
+		err := c.loadSnapshot(syncTime.Add(time.Minute))
+		assert.NoError(t, err)
+
+		assert.NoError(t, c.WaitForSync(context.Background()))
+		assert.Equal(t, syncTime.Unix(), c.GetLastSuccessfulSyncTime().Unix())
+
+		k, ok := c.GetAPIKeyBySecret("s0")
+		assert.True(t, ok)
+		assert.Equal(t, "k0", k.KeyID)
+		_, ok = c.GetClusterByRegistrationKey("rkey0")
+		assert.True(t, ok)
+		_, ok = c.GetUserByID("u0")
+		assert.True(t, ok)
+
+		pus := c.GetProjectsByUserID("u0")
+		assert.Len(t, pus, 1)
+		p, ok := c.GetProjectByID("p0")
+		assert.True(t, ok)
+		assert.Same(t, p, pus[0].Project)
+	})
+
+	t.Run("too old", func(t *testing.T) {
+		c := NewStore(nil, nil, StoreOpts{Snapshot: opts})
+		err := c.loadSnapshot(syncTime.Add(2 * time.Hour))
+		assert.Error(t, err)
+	})
+
+	t.Run("wrong key", func(t *testing.T) {
+		c := NewStore(nil, nil, StoreOpts{
+			Snapshot: &SnapshotOpts{
+				Path:          path,
+				EncryptionKey: []byte("another key"),
+				MaxAge:        time.Hour,
+			},
+		})
+		err := c.loadSnapshot(syncTime.Add(time.Minute))
+		assert.Error(t, err)
+	})
+}
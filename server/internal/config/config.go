@@ -3,8 +3,11 @@ package config
 import (
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/llmariner/rbac-manager/server/internal/role"
+	"github.com/llmariner/rbac-manager/server/internal/scope"
 	"gopkg.in/yaml.v3"
 )
 
@@ -22,8 +25,30 @@ type Config struct {
 
 	CacheConfig CacheConfig `yaml:"cache"`
 
-	// RoleScopesMap maps a role name to a list of scopes.
-	RoleScopesMap map[string][]string `yaml:"roleScopesMap"`
+	// RoleScopesMap maps a role name to its configuration. A scope can contain "*" segments,
+	// and a scope prefixed with "!" denies matching scopes.
+	// See the scope package for the syntax.
+	RoleScopesMap map[string]RoleConfig `yaml:"roleScopesMap"`
+}
+
+// RoleConfig is the configuration of a role.
+//
+// A role can be written either as a list of scopes or as a mapping with "scopes" and
+// "inherits".
+type RoleConfig struct {
+	Scopes []string `yaml:"scopes"`
+	// Inherits is a list of roles whose scopes are included in this role.
+	Inherits []string `yaml:"inherits"`
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (r *RoleConfig) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.SequenceNode {
+		return value.Decode(&r.Scopes)
+	}
+	// Use a different type to avoid calling UnmarshalYAML recursively.
+	type roleConfig RoleConfig
+	return value.Decode((*roleConfig)(r))
 }
 
 // Validate validates the configuration.
@@ -44,14 +69,145 @@ func (c *Config) Validate() error {
 	if err := c.CacheConfig.validate(); err != nil {
 		return fmt.Errorf("cache: %s", err)
 	}
+	if err := c.validateRoleScopesMap(); err != nil {
+		return fmt.Errorf("roleScopesMap: %s", err)
+	}
 	return nil
 }
 
+func (c *Config) validateRoleScopesMap() error {
+	for _, r := range role.BuiltInRoles() {
+		if _, ok := c.RoleScopesMap[r]; !ok {
+			return fmt.Errorf("role %q must be set", r)
+		}
+	}
+	for name, r := range c.RoleScopesMap {
+		for _, s := range r.Scopes {
+			if err := scope.Validate(s); err != nil {
+				return fmt.Errorf("role %q: %s", name, err)
+			}
+			if err := scope.ValidateForm(s); err != nil {
+				return fmt.Errorf("role %q: %s", name, err)
+			}
+		}
+	}
+	if _, err := c.RoleScopes(); err != nil {
+		return err
+	}
+	return nil
+}
+
+// RoleScopes returns a map from a role name to its scopes, including the scopes
+// inherited from other roles.
+//
+// Deny scopes are inherited as well, so a role cannot allow a scope that is denied
+// by one of the roles it inherits.
+func (c *Config) RoleScopes() (map[string][]string, error) {
+	r := &roleResolver{
+		roles:    c.RoleScopesMap,
+		resolved: map[string][]string{},
+		visiting: map[string]bool{},
+	}
+	for name := range c.RoleScopesMap {
+		if _, err := r.resolve(name, nil); err != nil {
+			return nil, err
+		}
+	}
+	return r.resolved, nil
+}
+
+type roleResolver struct {
+	roles    map[string]RoleConfig
+	resolved map[string][]string
+	// visiting is the set of roles that are being resolved. It is used to detect cycles.
+	visiting map[string]bool
+}
+
+func (r *roleResolver) resolve(name string, path []string) ([]string, error) {
+	if scopes, ok := r.resolved[name]; ok {
+		return scopes, nil
+	}
+	path = append(path, name)
+	if r.visiting[name] {
+		return nil, fmt.Errorf("role %q has an inheritance cycle: %s", name, strings.Join(path, " -> "))
+	}
+	r.visiting[name] = true
+	defer delete(r.visiting, name)
+
+	role := r.roles[name]
+	var scopes []string
+	seen := map[string]bool{}
+	add := func(ss []string) {
+		for _, s := range ss {
+			if !seen[s] {
+				seen[s] = true
+				scopes = append(scopes, s)
+			}
+		}
+	}
+	for _, parent := range role.Inherits {
+		if _, ok := r.roles[parent]; !ok {
+			return nil, fmt.Errorf("role %q inherits unknown role %q", name, parent)
+		}
+		ss, err := r.resolve(parent, path)
+		if err != nil {
+			return nil, err
+		}
+		add(ss)
+	}
+	add(role.Scopes)
+
+	r.resolved[name] = scopes
+	return scopes, nil
+}
+
 // CacheConfig is the API key cache configuration.
 type CacheConfig struct {
 	SyncInterval                     time.Duration `yaml:"syncInterval"`
 	UserManagerServerInternalAddr    string        `yaml:"userManagerServerInternalAddr"`
 	ClusterManagerServerInternalAddr string        `yaml:"clusterManagerServerInternalAddr"`
+
+	// MaxRecvMsgSize is the maximum size in bytes of a response from user-manager-server and
+	// cluster-manager-server. The gRPC default (4 MiB) is used if zero.
+	MaxRecvMsgSize int `yaml:"maxRecvMsgSize"`
+
+	// StrictSync makes a sync fail if any record from user-manager-server is inconsistent.
+	// Otherwise, inconsistent records are skipped.
+	StrictSync bool `yaml:"strictSync"`
+
+	// StalenessThreshold is the duration after which the cache is considered stale since the last
+	// successful sync. The server becomes unready while the cache is stale. The staleness is not
+	// checked if zero.
+	StalenessThreshold time.Duration `yaml:"stalenessThreshold"`
+
+	// Snapshot is the configuration of the on-disk snapshot of the cache.
+	Snapshot SnapshotConfig `yaml:"snapshot"`
+}
+
+// SnapshotConfig is the configuration of the on-disk snapshot of the cache.
+// The snapshot is loaded at startup when user-manager-server or cluster-manager-server
+// is unavailable.
+type SnapshotConfig struct {
+	// Path is the path of the snapshot file. The snapshot is disabled if empty.
+	Path string `yaml:"path"`
+	// EncryptionKeyFile is the path of the file that contains the key used to encrypt the snapshot.
+	EncryptionKeyFile string `yaml:"encryptionKeyFile"`
+	// MaxAge is the maximum age of a snapshot that can be loaded. It must not exceed
+	// StalenessThreshold if set, as the age of a loaded snapshot counts toward the staleness.
+	MaxAge time.Duration `yaml:"maxAge"`
+}
+
+func (c *SnapshotConfig) validate() error {
+	if c.Path == "" {
+		return nil
+	}
+	if c.EncryptionKeyFile == "" {
+		return fmt.Errorf("encryptionKeyFile must be set")
+	}
+	if c.MaxAge <= 0 {
+		return fmt.Errorf("maxAge must be greater than 0")
+	}
+	return nil
 }
 
 func (c *CacheConfig) validate() error {
@@ -64,6 +220,18 @@ func (c *CacheConfig) validate() error {
 	if c.ClusterManagerServerInternalAddr == "" {
 		return fmt.Errorf("clusterManagerServerInternalAddr must be set")
 	}
+	if c.MaxRecvMsgSize < 0 {
+		return fmt.Errorf("maxRecvMsgSize must be greater than or equal to 0")
+	}
+	if c.StalenessThreshold < 0 {
+		return fmt.Errorf("stalenessThreshold must be greater than or equal to 0")
+	}
+	if err := c.Snapshot.validate(); err != nil {
+		return fmt.Errorf("snapshot: %s", err)
+	}
+	if c.Snapshot.Path != "" && c.StalenessThreshold > 0 && c.Snapshot.MaxAge > c.StalenessThreshold {
+		return fmt.Errorf("snapshot: maxAge must be less than or equal to stalenessThreshold")
+	}
 	return nil
 }
 
@@ -0,0 +1,266 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestRoleConfig_Unmarshal(t *testing.T) {
+	b := []byte(`
+roleScopesMap:
+  projectMember:
+  - api.files.read
+  projectOwner:
+    inherits:
+    - projectMember
+    scopes:
+    - api.files.write
+`)
+	var c Config
+	err := yaml.Unmarshal(b, &c)
+	assert.NoError(t, err)
+
+	want := map[string]RoleConfig{
+		"projectMember": {
+			Scopes: []string{"api.files.read"},
+		},
+		"projectOwner": {
+			Scopes:   []string{"api.files.write"},
+			Inherits: []string{"projectMember"},
+		},
+	}
+	assert.Equal(t, want, c.RoleScopesMap)
+}
+
+func TestRoleScopes(t *testing.T) {
+	tcs := []struct {
+		name    string
+		roles   map[string]RoleConfig
+		want    map[string][]string
+		wantErr bool
+	}{
+		{
+			name: "no inheritance",
+			roles: map[string]RoleConfig{
+				"projectMember": {Scopes: []string{"api.files.read"}},
+			},
+			want: map[string][]string{
+				"projectMember": {"api.files.read"},
+			},
+		},
+		{
+			name: "transitive inheritance",
+			roles: map[string]RoleConfig{
+				"projectMember": {Scopes: []string{"api.files.read", "!api.clusters.write"}},
+				"projectOwner": {
+					Scopes:   []string{"api.files.read", "api.files.write"},
+					Inherits: []string{"projectMember"},
+				},
+				"organizationOwner": {
+					Scopes:   []string{"api.clusters.read"},
+					Inherits: []string{"projectOwner"},
+				},
+			},
+			want: map[string][]string{
+				"projectMember":     {"api.files.read", "!api.clusters.write"},
+				"projectOwner":      {"api.files.read", "!api.clusters.write", "api.files.write"},
+				"organizationOwner": {"api.files.read", "!api.clusters.write", "api.files.write", "api.clusters.read"},
+			},
+		},
+		{
+			name: "multiple parents",
+			roles: map[string]RoleConfig{
+				"a": {Scopes: []string{"api.a.read"}},
+				"b": {Scopes: []string{"api.b.read"}},
+				"c": {Inherits: []string{"a", "b"}},
+			},
+			want: map[string][]string{
+				"a": {"api.a.read"},
+				"b": {"api.b.read"},
+				"c": {"api.a.read", "api.b.read"},
+			},
+		},
+		{
+			name: "unknown role",
+			roles: map[string]RoleConfig{
+				"projectOwner": {Inherits: []string{"projectMembr"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "self inheritance",
+			roles: map[string]RoleConfig{
+				"a": {Inherits: []string{"a"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "cycle",
+			roles: map[string]RoleConfig{
+				"a": {Inherits: []string{"b"}},
+				"b": {Inherits: []string{"c"}},
+				"c": {Inherits: []string{"a"}},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			c := &Config{RoleScopesMap: tc.roles}
+			got, err := c.RoleScopes()
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestValidateRoleScopesMap(t *testing.T) {
+	validRoles := func() map[string]RoleConfig {
+		return map[string]RoleConfig{
+			"organizationOwner": {Inherits: []string{"projectOwner"}},
+			"tenantSystem":      {Scopes: []string{"api.clusters.read"}},
+			"projectOwner":      {Inherits: []string{"projectMember"}},
+			"projectMember":     {Scopes: []string{"api.files.read", "api.fine_tuning.*", "!api.files.write"}},
+		}
+	}
+
+	tcs := []struct {
+		name    string
+		update  func(map[string]RoleConfig)
+		wantErr bool
+	}{
+		{
+			name:   "valid",
+			update: func(map[string]RoleConfig) {},
+		},
+		{
+			name: "custom role",
+			update: func(roles map[string]RoleConfig) {
+				roles["billingViewer"] = RoleConfig{Scopes: []string{"api.api_usages.read"}}
+			},
+		},
+		{
+			name: "missing built-in role",
+			update: func(roles map[string]RoleConfig) {
+				delete(roles, "projectMember")
+				roles["projectMembers"] = RoleConfig{Scopes: []string{"api.files.read"}}
+				roles["projectOwner"] = RoleConfig{Inherits: []string{"projectMembers"}}
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid scope syntax",
+			update: func(roles map[string]RoleConfig) {
+				roles["tenantSystem"] = RoleConfig{Scopes: []string{"api..read"}}
+			},
+			wantErr: true,
+		},
+		{
+			name: "missing capability",
+			update: func(roles map[string]RoleConfig) {
+				roles["tenantSystem"] = RoleConfig{Scopes: []string{"clusters"}}
+			},
+			wantErr: true,
+		},
+		{
+			name: "custom capability",
+			update: func(roles map[string]RoleConfig) {
+				roles["tenantSystem"] = RoleConfig{Scopes: []string{"api.clusters.purge"}}
+			},
+		},
+		{
+			name: "unknown inherited role",
+			update: func(roles map[string]RoleConfig) {
+				roles["projectOwner"] = RoleConfig{Inherits: []string{"projectMembr"}}
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			roles := validRoles()
+			tc.update(roles)
+			c := &Config{RoleScopesMap: roles}
+			err := c.validateRoleScopesMap()
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestCacheConfig_Validate(t *testing.T) {
+	validConfig := func() CacheConfig {
+		return CacheConfig{
+			SyncInterval:                     time.Minute,
+			UserManagerServerInternalAddr:    "user-manager-server:8082",
+			ClusterManagerServerInternalAddr: "cluster-manager-server:8083",
+			StalenessThreshold:               time.Hour,
+			Snapshot: SnapshotConfig{
+				Path:              "/var/lib/rbac-server/snapshot",
+				EncryptionKeyFile: "/etc/rbac-server/snapshot-key",
+				MaxAge:            time.Hour,
+			},
+		}
+	}
+
+	tcs := []struct {
+		name    string
+		update  func(*CacheConfig)
+		wantErr bool
+	}{
+		{
+			name:   "valid",
+			update: func(*CacheConfig) {},
+		},
+		{
+			name: "snapshot without staleness threshold",
+			update: func(c *CacheConfig) {
+				c.StalenessThreshold = 0
+				c.Snapshot.MaxAge = 24 * time.Hour
+			},
+		},
+		{
+			name: "snapshot max age exceeding staleness threshold",
+			update: func(c *CacheConfig) {
+				c.Snapshot.MaxAge = 24 * time.Hour
+			},
+			wantErr: true,
+		},
+		{
+			name: "staleness threshold without snapshot",
+			update: func(c *CacheConfig) {
+				c.Snapshot = SnapshotConfig{}
+				c.StalenessThreshold = time.Minute
+			},
+		},
+		{
+			name: "snapshot without encryption key",
+			update: func(c *CacheConfig) {
+				c.Snapshot.EncryptionKeyFile = ""
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			c := validConfig()
+			tc.update(&c)
+			err := c.validate()
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
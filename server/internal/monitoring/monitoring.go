@@ -12,7 +12,8 @@ import (
 const (
 	metricNamespace = "llmariner"
 
-	metricsNameSinceLastCacheSyncSec = "rbac_server_since_last_cache_sync_sec"
+	metricsNameSinceLastCacheSyncSec   = "rbac_server_since_last_cache_sync_sec"
+	metricsNameCacheSyncSkippedRecords = "rbac_server_cache_sync_skipped_records"
 )
 
 // MetricsMonitor holds and updates Prometheus metrics.
@@ -20,7 +21,8 @@ type MetricsMonitor struct {
 	cstore *cache.Store
 	logger logr.Logger
 
-	sinceLastCacheSyncSecGauge prometheus.Gauge
+	sinceLastCacheSyncSecGauge   prometheus.Gauge
+	cacheSyncSkippedRecordsGauge prometheus.Gauge
 }
 
 // NewMetricsMonitor returns a new MetricsMonitor.
@@ -32,14 +34,24 @@ func NewMetricsMonitor(cstore *cache.Store, logger logr.Logger) *MetricsMonitor
 		},
 	)
 
+	cacheSyncSkippedRecordsGauge := prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: metricNamespace,
+			Name:      metricsNameCacheSyncSkippedRecords,
+			Help:      "The number of inconsistent records skipped in the last successful cache sync.",
+		},
+	)
+
 	m := &MetricsMonitor{
-		cstore:                     cstore,
-		logger:                     logger.WithName("monitor"),
-		sinceLastCacheSyncSecGauge: sinceLastCacheSyncSecGauge,
+		cstore:                       cstore,
+		logger:                       logger.WithName("monitor"),
+		sinceLastCacheSyncSecGauge:   sinceLastCacheSyncSecGauge,
+		cacheSyncSkippedRecordsGauge: cacheSyncSkippedRecordsGauge,
 	}
 
 	prometheus.MustRegister(
 		m.sinceLastCacheSyncSecGauge,
+		m.cacheSyncSkippedRecordsGauge,
 	)
 
 	return m
@@ -54,6 +66,7 @@ func (m *MetricsMonitor) Run(ctx context.Context, interval time.Duration) error
 		case <-ticker.C:
 			t := time.Since(m.cstore.GetLastSuccessfulSyncTime())
 			m.sinceLastCacheSyncSecGauge.Set(float64(t.Seconds()))
+			m.cacheSyncSkippedRecordsGauge.Set(float64(m.cstore.GetLastSkippedRecordCount()))
 		case <-ctx.Done():
 			return ctx.Err()
 		}
@@ -63,4 +76,5 @@ func (m *MetricsMonitor) Run(ctx context.Context, interval time.Duration) error
 // UnregisterAllCollectors unregisters all connectors.
 func (m *MetricsMonitor) UnregisterAllCollectors() {
 	prometheus.Unregister(m.sinceLastCacheSyncSecGauge)
+	prometheus.Unregister(m.cacheSyncSkippedRecordsGauge)
 }
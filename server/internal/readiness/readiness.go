@@ -0,0 +1,110 @@
+package readiness
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+type syncTimeGetter interface {
+	GetLastSuccessfulSyncTime() time.Time
+}
+
+type servingStatusSetter interface {
+	SetServing(serving bool)
+}
+
+// NewChecker returns a new Checker.
+//
+// The cache is considered stale if the last successful sync is older than stalenessThreshold.
+// The staleness is not checked if stalenessThreshold is zero.
+func NewChecker(cstore syncTimeGetter, stalenessThreshold time.Duration, logger logr.Logger) *Checker {
+	return &Checker{
+		cstore:             cstore,
+		stalenessThreshold: stalenessThreshold,
+		logger:             logger.WithName("readiness"),
+		now:                time.Now,
+	}
+}
+
+// Checker checks if the server is ready based on the freshness of the cache.
+type Checker struct {
+	cstore             syncTimeGetter
+	stalenessThreshold time.Duration
+	logger             logr.Logger
+
+	mu sync.Mutex
+	// ready is the result of the last check done in Run.
+	ready *bool
+
+	// now returns the current time. It is replaced in tests.
+	now func() time.Time
+}
+
+// Check returns an error if the server is not ready.
+func (c *Checker) Check() error {
+	t := c.cstore.GetLastSuccessfulSyncTime()
+	if t.IsZero() {
+		return fmt.Errorf("cache has not been synced")
+	}
+	if c.stalenessThreshold > 0 {
+		if d := c.now().Sub(t); d > c.stalenessThreshold {
+			return fmt.Errorf("cache is stale: last synced at %s", t.Format(time.RFC3339))
+		}
+	}
+	return nil
+}
+
+// Run checks the readiness periodically and updates the serving status when it changes.
+func (c *Checker) Run(ctx context.Context, interval time.Duration, s servingStatusSetter) error {
+	c.logger.Info("Starting readiness checker...", "interval", interval, "stalenessThreshold", c.stalenessThreshold)
+	c.update(s)
+	ticker := time.NewTicker(interval)
+	for {
+		select {
+		case <-ticker.C:
+			c.update(s)
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+func (c *Checker) update(s servingStatusSetter) {
+	err := c.Check()
+	ready := err == nil
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ready != nil && *c.ready == ready {
+		return
+	}
+	c.ready = &ready
+
+	if ready {
+		c.logger.Info("Server is ready")
+	} else {
+		c.logger.Info("Server is not ready", "reason", err.Error())
+	}
+	s.SetServing(ready)
+}
+
+// ServeHealthz serves the liveness endpoint. It always succeeds while the process is running.
+func (c *Checker) ServeHealthz(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok\n"))
+}
+
+// ServeReadyz serves the readiness endpoint.
+func (c *Checker) ServeReadyz(w http.ResponseWriter, r *http.Request) {
+	if err := c.Check(); err != nil {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok\n"))
+}
@@ -0,0 +1,115 @@
+package readiness
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck(t *testing.T) {
+	now := time.Now()
+	tcs := []struct {
+		name      string
+		syncTime  time.Time
+		threshold time.Duration
+		wantErr   bool
+	}{
+		{
+			name:      "fresh",
+			syncTime:  now.Add(-time.Minute),
+			threshold: 5 * time.Minute,
+		},
+		{
+			name:      "stale",
+			syncTime:  now.Add(-10 * time.Minute),
+			threshold: 5 * time.Minute,
+			wantErr:   true,
+		},
+		{
+			name:     "staleness not checked",
+			syncTime: now.Add(-10 * time.Minute),
+		},
+		{
+			name:      "not synced",
+			threshold: 5 * time.Minute,
+			wantErr:   true,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			c := NewChecker(&fakeSyncTimeGetter{t: tc.syncTime}, tc.threshold, logr.Discard())
+			c.now = func() time.Time { return now }
+			err := c.Check()
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	now := time.Now()
+	g := &fakeSyncTimeGetter{t: now}
+	c := NewChecker(g, time.Minute, logr.Discard())
+	c.now = func() time.Time { return now }
+	s := &fakeServingStatusSetter{}
+
+	c.update(s)
+	assert.Equal(t, []bool{true}, s.statuses)
+
+	// The status is not set again if unchanged.
+	c.update(s)
+	assert.Equal(t, []bool{true}, s.statuses)
+
+	now = now.Add(2 * time.Minute)
+	c.update(s)
+	assert.Equal(t, []bool{true, false}, s.statuses)
+
+	// The sync recovers.
+	g.t = now
+	c.update(s)
+	assert.Equal(t, []bool{true, false, true}, s.statuses)
+}
+
+func TestServeReadyz(t *testing.T) {
+	now := time.Now()
+	g := &fakeSyncTimeGetter{t: now}
+	c := NewChecker(g, time.Minute, logr.Discard())
+	c.now = func() time.Time { return now }
+
+	rec := httptest.NewRecorder()
+	c.ServeReadyz(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	now = now.Add(2 * time.Minute)
+	rec = httptest.NewRecorder()
+	c.ServeReadyz(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+
+	// The liveness endpoint is not affected by the staleness.
+	rec = httptest.NewRecorder()
+	c.ServeHealthz(rec, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+type fakeSyncTimeGetter struct {
+	t time.Time
+}
+
+func (g *fakeSyncTimeGetter) GetLastSuccessfulSyncTime() time.Time {
+	return g.t
+}
+
+type fakeServingStatusSetter struct {
+	statuses []bool
+}
+
+func (s *fakeServingStatusSetter) SetServing(serving bool) {
+	s.statuses = append(s.statuses, serving)
+}
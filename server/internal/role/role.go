@@ -0,0 +1,23 @@
+// Package role defines the names of the built-in roles that are configured in roleScopesMap.
+package role
+
+const (
+	// OrganizationOwner is the role of a user who has the owner role for an organization.
+	OrganizationOwner = "organizationOwner"
+	// TenantSystem is the role of a user who has the tenant system role for an organization.
+	TenantSystem = "tenantSystem"
+	// ProjectOwner is the role of a user who has the owner role for a project.
+	ProjectOwner = "projectOwner"
+	// ProjectMember is the role of a user who has the member role for a project.
+	ProjectMember = "projectMember"
+)
+
+// BuiltInRoles returns the names of the roles that the server maps organization and project roles to.
+func BuiltInRoles() []string {
+	return []string{
+		OrganizationOwner,
+		TenantSystem,
+		ProjectOwner,
+		ProjectMember,
+	}
+}
@@ -0,0 +1,149 @@
+package scope
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	separator = "."
+	wildcard  = "*"
+	// denyPrefix is the prefix of a pattern that denies matching scopes.
+	denyPrefix = "!"
+)
+
+// Validate validates a scope pattern.
+//
+// A pattern is a list of segments separated by ".", such as "api.files.read". A segment
+// can be "*", which matches one or more segments. For example, "api.fine_tuning.*" matches
+// "api.fine_tuning.jobs.read", and "api.*.read" matches both "api.files.read" and
+// "api.fine_tuning.jobs.read". A segment cannot mix "*" with other characters.
+//
+// A pattern prefixed with "!" is a deny pattern, such as "!api.clusters.write".
+func Validate(pattern string) error {
+	p := strings.TrimPrefix(pattern, denyPrefix)
+	if p == "" {
+		return fmt.Errorf("empty scope")
+	}
+	for _, seg := range strings.Split(p, separator) {
+		if seg == "" {
+			return fmt.Errorf("scope %q has an empty segment", pattern)
+		}
+		if seg != wildcard && strings.Contains(seg, wildcard) {
+			return fmt.Errorf("scope %q has a partial wildcard segment %q", pattern, seg)
+		}
+		if strings.Contains(seg, denyPrefix) {
+			return fmt.Errorf("scope %q has %q in a segment", pattern, denyPrefix)
+		}
+	}
+	return nil
+}
+
+// ValidateForm validates that the scope pattern has the "<resource>.<capability>" form. The
+// capability is not restricted to a fixed set as services can define their own capabilities.
+// A pattern ending with "*" is accepted as the wildcard covers the capability.
+//
+// The pattern must be valid per Validate.
+func ValidateForm(pattern string) error {
+	segs := strings.Split(strings.TrimPrefix(pattern, denyPrefix), separator)
+	last := segs[len(segs)-1]
+	if last == wildcard {
+		return nil
+	}
+	if len(segs) < 2 {
+		return fmt.Errorf("scope %q does not have the <resource>.<capability> form", pattern)
+	}
+	return nil
+}
+
+// Compile compiles the scope patterns into a Matcher.
+func Compile(patterns []string) (*Matcher, error) {
+	m := &Matcher{
+		allow: newPatternSet(),
+		deny:  newPatternSet(),
+	}
+	for _, p := range patterns {
+		if err := Validate(p); err != nil {
+			return nil, err
+		}
+		if d, ok := strings.CutPrefix(p, denyPrefix); ok {
+			m.deny.add(d)
+			continue
+		}
+		m.allow.add(p)
+	}
+	return m, nil
+}
+
+// Matcher matches scopes against a set of compiled patterns.
+//
+// A scope is allowed if it matches one of the allow patterns and does not match any of the
+// deny patterns. In other words, a deny pattern always takes precedence over allow patterns,
+// regardless of how specific the patterns are. The order of the patterns does not matter.
+type Matcher struct {
+	allow *patternSet
+	deny  *patternSet
+}
+
+// Match returns true if the scope is allowed.
+func (m *Matcher) Match(scope string) bool {
+	return !m.deny.match(scope) && m.allow.match(scope)
+}
+
+// Denied returns true if the scope matches one of the deny patterns.
+func (m *Matcher) Denied(scope string) bool {
+	return m.deny.match(scope)
+}
+
+func newPatternSet() *patternSet {
+	return &patternSet{
+		exact: map[string]bool{},
+	}
+}
+
+// patternSet is a set of patterns. Patterns without a wildcard are kept in a map
+// so that they can be matched without splitting the scope.
+type patternSet struct {
+	exact    map[string]bool
+	patterns [][]string
+}
+
+func (s *patternSet) add(pattern string) {
+	if !strings.Contains(pattern, wildcard) {
+		s.exact[pattern] = true
+		return
+	}
+	s.patterns = append(s.patterns, strings.Split(pattern, separator))
+}
+
+func (s *patternSet) match(scope string) bool {
+	if s.exact[scope] {
+		return true
+	}
+	if len(s.patterns) == 0 {
+		return false
+	}
+	segs := strings.Split(scope, separator)
+	for _, p := range s.patterns {
+		if matchSegments(p, segs) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchSegments(pattern, segs []string) bool {
+	if len(pattern) == 0 {
+		return len(segs) == 0
+	}
+	if pattern[0] != wildcard {
+		return len(segs) > 0 && pattern[0] == segs[0] && matchSegments(pattern[1:], segs[1:])
+	}
+	// The wildcard consumes one or more segments.
+	for i := 1; i <= len(segs); i++ {
+		if matchSegments(pattern[1:], segs[i:]) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,132 @@
+package scope
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	tcs := []struct {
+		pattern string
+		wantErr bool
+	}{
+		{pattern: "api.files.read"},
+		{pattern: "api.*.read"},
+		{pattern: "api.fine_tuning.*"},
+		{pattern: "*"},
+		{pattern: "!api.clusters.write"},
+		{pattern: "!api.*.write"},
+		{pattern: "", wantErr: true},
+		{pattern: "api..read", wantErr: true},
+		{pattern: "api.files.", wantErr: true},
+		{pattern: "api.file*.read", wantErr: true},
+		{pattern: "!", wantErr: true},
+		{pattern: "!!api.files.read", wantErr: true},
+		{pattern: "api.!files.read", wantErr: true},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.pattern, func(t *testing.T) {
+			err := Validate(tc.pattern)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestValidateForm(t *testing.T) {
+	tcs := []struct {
+		pattern string
+		wantErr bool
+	}{
+		{pattern: "api.files.read"},
+		{pattern: "api.files.write"},
+		{pattern: "api.fine_tuning.*"},
+		{pattern: "api.*.read"},
+		{pattern: "*"},
+		{pattern: "!api.clusters.write"},
+		{pattern: "read", wantErr: true},
+		{pattern: "api.files.purge"},
+		{pattern: "!api.files.delete"},
+		{pattern: "api.clusters.admin"},
+		{pattern: "!api.files.purge"},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.pattern, func(t *testing.T) {
+			err := ValidateForm(tc.pattern)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	m, err := Compile([]string{
+		"api.files.read",
+		"api.*.read",
+		"api.fine_tuning.*",
+		"api.k8s.namespaced.*",
+	})
+	assert.NoError(t, err)
+
+	tcs := []struct {
+		scope string
+		want  bool
+	}{
+		{scope: "api.files.read", want: true},
+		{scope: "api.model.read", want: true},
+		{scope: "api.workspaces.notebooks.read", want: true},
+		{scope: "api.fine_tuning.jobs.read", want: true},
+		{scope: "api.fine_tuning.jobs.write", want: true},
+		{scope: "api.k8s.namespaced.write", want: true},
+		{scope: "api.files.write", want: false},
+		{scope: "api.read", want: false},
+		{scope: "api.fine_tuning", want: false},
+		{scope: "api.k8s.clusterscope.write", want: false},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.scope, func(t *testing.T) {
+			assert.Equal(t, tc.want, m.Match(tc.scope))
+		})
+	}
+}
+
+func TestMatch_Deny(t *testing.T) {
+	m, err := Compile([]string{
+		"!api.clusters.write",
+		"api.*",
+		"!api.workspaces.*",
+		"api.workspaces.notebooks.read",
+	})
+	assert.NoError(t, err)
+
+	tcs := []struct {
+		scope      string
+		want       bool
+		wantDenied bool
+	}{
+		{scope: "api.clusters.read", want: true},
+		{scope: "api.files.write", want: true},
+		{scope: "api.clusters.write", want: false, wantDenied: true},
+		// A deny pattern takes precedence over a more specific allow pattern.
+		{scope: "api.workspaces.notebooks.read", want: false, wantDenied: true},
+		{scope: "other.files.read", want: false},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.scope, func(t *testing.T) {
+			assert.Equal(t, tc.want, m.Match(tc.scope))
+			assert.Equal(t, tc.wantDenied, m.Denied(tc.scope))
+		})
+	}
+}
+
+func TestCompile_Invalid(t *testing.T) {
+	_, err := Compile([]string{"api.files.read", "api.fi*"})
+	assert.Error(t, err)
+}
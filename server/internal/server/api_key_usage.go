@@ -0,0 +1,113 @@
+package server
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	v1 "github.com/llmariner/rbac-manager/api/v1"
+	"github.com/llmariner/rbac-manager/server/internal/cache"
+)
+
+// ListAPIKeyUsages lists the usages of API keys recorded by this server.
+func (s *Server) ListAPIKeyUsages(ctx context.Context, req *v1.ListAPIKeyUsagesRequest) (*v1.ListAPIKeyUsagesResponse, error) {
+	s.pruneAPIKeyUsages()
+
+	var usages []*v1.APIKeyUsage
+	for _, u := range s.apiKeyUsages.list(req.TenantId) {
+		usages = append(usages, &v1.APIKeyUsage{
+			ApiKeyId:     u.keyID,
+			TenantId:     u.tenantID,
+			LastUsedAt:   u.lastUsedAt.Unix(),
+			RequestCount: u.requestCount,
+		})
+	}
+	return &v1.ListAPIKeyUsagesResponse{Usages: usages}, nil
+}
+
+// recordAPIKeyUsage records an authorized request made with the API key.
+func (s *Server) recordAPIKeyUsage(key *cache.K) {
+	s.apiKeyUsages.record(key.KeyID, key.TenantID, s.now())
+	s.pruneAPIKeyUsages()
+}
+
+// pruneAPIKeyUsages removes the usages of the API keys that are no longer in the cache.
+func (s *Server) pruneAPIKeyUsages() {
+	s.apiKeyUsages.prune(s.cache.GetLastSuccessfulSyncTime(), s.cache.GetAPIKeyIDs)
+}
+
+type apiKeyUsage struct {
+	keyID        string
+	tenantID     string
+	lastUsedAt   time.Time
+	requestCount int64
+}
+
+func newAPIKeyUsageTracker() *apiKeyUsageTracker {
+	return &apiKeyUsageTracker{
+		usagesByKeyID: map[string]*apiKeyUsage{},
+	}
+}
+
+// apiKeyUsageTracker aggregates the last-used time and the number of authorized requests per API key.
+type apiKeyUsageTracker struct {
+	mu            sync.Mutex
+	usagesByKeyID map[string]*apiKeyUsage
+	// lastPrunedSyncTime is the cache sync time at the last pruning.
+	lastPrunedSyncTime time.Time
+}
+
+func (t *apiKeyUsageTracker) record(keyID, tenantID string, now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	u, ok := t.usagesByKeyID[keyID]
+	if !ok {
+		u = &apiKeyUsage{
+			keyID:    keyID,
+			tenantID: tenantID,
+		}
+		t.usagesByKeyID[keyID] = u
+	}
+	if now.After(u.lastUsedAt) {
+		u.lastUsedAt = now
+	}
+	u.requestCount++
+}
+
+// prune removes the usages of the API keys whose IDs are not returned by getKeyIDs. It runs at
+// most once per cache sync so that the key IDs are not collected on every request.
+func (t *apiKeyUsageTracker) prune(syncTime time.Time, getKeyIDs func() map[string]bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !syncTime.After(t.lastPrunedSyncTime) {
+		return
+	}
+	keyIDs := getKeyIDs()
+	for id := range t.usagesByKeyID {
+		if !keyIDs[id] {
+			delete(t.usagesByKeyID, id)
+		}
+	}
+	t.lastPrunedSyncTime = syncTime
+}
+
+// list returns the usages sorted by key ID. If tenantID is not empty, only the usages of the tenant are returned.
+func (t *apiKeyUsageTracker) list(tenantID string) []apiKeyUsage {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var us []apiKeyUsage
+	for _, u := range t.usagesByKeyID {
+		if tenantID != "" && u.tenantID != tenantID {
+			continue
+		}
+		us = append(us, *u)
+	}
+	sort.Slice(us, func(i, j int) bool {
+		return us[i].keyID < us[j].keyID
+	})
+	return us
+}
@@ -0,0 +1,127 @@
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	v1 "github.com/llmariner/rbac-manager/api/v1"
+	"github.com/llmariner/rbac-manager/server/internal/cache"
+	uv1 "github.com/llmariner/user-manager/api/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestListAPIKeyUsages(t *testing.T) {
+	cg := &fakeCacheGetter{
+		apikeys: map[string]*cache.K{
+			"s0": {
+				KeyID:            "k0",
+				TenantID:         "t0",
+				ProjectID:        "p0",
+				OrganizationID:   "o0",
+				OrganizationRole: uv1.OrganizationRole_ORGANIZATION_ROLE_OWNER,
+			},
+			"s1": {
+				KeyID:            "k1",
+				TenantID:         "t1",
+				ProjectID:        "p1",
+				OrganizationID:   "o1",
+				OrganizationRole: uv1.OrganizationRole_ORGANIZATION_ROLE_OWNER,
+			},
+		},
+		orgsByID: map[string]*cache.O{
+			"o0": {ID: "o0", TenantID: "t0"},
+			"o1": {ID: "o1", TenantID: "t1"},
+		},
+		projectsByID: map[string]*cache.P{
+			"p0": {ID: "p0", OrganizationID: "o0"},
+			"p1": {ID: "p1", OrganizationID: "o1"},
+		},
+	}
+	srv, err := New(
+		&fakeTokenIntrospector{},
+		cg,
+		map[string][]string{
+			"organizationOwner": {"api.object.read"},
+		},
+	)
+	assert.NoError(t, err)
+
+	now := time.Unix(1000, 0)
+	srv.now = func() time.Time { return now }
+
+	authorize := func(token, capability string) {
+		_, err := srv.Authorize(context.Background(), &v1.AuthorizeRequest{
+			Token:          token,
+			AccessResource: "api.object",
+			Capability:     capability,
+		})
+		assert.NoError(t, err)
+	}
+
+	authorize("s0", "read")
+	now = now.Add(time.Minute)
+	authorize("s0", "read")
+	authorize("s1", "read")
+	// Denied requests are not recorded.
+	now = now.Add(time.Minute)
+	authorize("s1", "write")
+
+	authorizeBatch := func(token string, capabilities ...string) {
+		var targets []*v1.AuthorizeBatchRequest_Target
+		for _, c := range capabilities {
+			targets = append(targets, &v1.AuthorizeBatchRequest_Target{
+				AccessResource: "api.object",
+				Capability:     c,
+			})
+		}
+		_, err := srv.AuthorizeBatch(context.Background(), &v1.AuthorizeBatchRequest{
+			Token:   token,
+			Targets: targets,
+		})
+		assert.NoError(t, err)
+	}
+
+	// A batch request is recorded once if any of the targets is authorized.
+	authorizeBatch("s1", "read", "read", "write")
+	authorizeBatch("s1", "write", "write")
+
+	resp, err := srv.ListAPIKeyUsages(context.Background(), &v1.ListAPIKeyUsagesRequest{})
+	assert.NoError(t, err)
+	want := []*v1.APIKeyUsage{
+		{
+			ApiKeyId:     "k0",
+			TenantId:     "t0",
+			LastUsedAt:   1060,
+			RequestCount: 2,
+		},
+		{
+			ApiKeyId:     "k1",
+			TenantId:     "t1",
+			LastUsedAt:   1120,
+			RequestCount: 2,
+		},
+	}
+	assert.Len(t, resp.Usages, len(want))
+	for i, w := range want {
+		assert.Truef(t, proto.Equal(w, resp.Usages[i]), "want %v, got %v", w, resp.Usages[i])
+	}
+
+	resp, err = srv.ListAPIKeyUsages(context.Background(), &v1.ListAPIKeyUsagesRequest{TenantId: "t1"})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Usages, 1)
+	assert.Equal(t, "k1", resp.Usages[0].ApiKeyId)
+
+	// The usage of a deleted API key is kept until the next sync.
+	delete(cg.apikeys, "s0")
+	resp, err = srv.ListAPIKeyUsages(context.Background(), &v1.ListAPIKeyUsagesRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Usages, 2)
+
+	cg.lastSuccessfulSyncTime = now
+	resp, err = srv.ListAPIKeyUsages(context.Background(), &v1.ListAPIKeyUsagesRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Usages, 1)
+	assert.Equal(t, "k1", resp.Usages[0].ApiKeyId)
+}
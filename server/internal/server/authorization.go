@@ -2,11 +2,14 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	v1 "github.com/llmariner/rbac-manager/api/v1"
 	"github.com/llmariner/rbac-manager/server/internal/cache"
+	"github.com/llmariner/rbac-manager/server/internal/role"
 	uv1 "github.com/llmariner/user-manager/api/v1"
 	"github.com/llmariner/user-manager/pkg/userid"
 	"google.golang.org/grpc/codes"
@@ -18,27 +21,128 @@ func (s *Server) Authorize(ctx context.Context, req *v1.AuthorizeRequest) (*v1.A
 	if req.Token == "" {
 		return nil, status.Errorf(codes.InvalidArgument, "token is required")
 	}
-	if req.AccessResource == "" {
-		return nil, status.Errorf(codes.InvalidArgument, "access resource is required")
+	if err := validateTarget(req.AccessResource, req.Capability); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", err)
 	}
-	if req.Capability == "" {
-		return nil, status.Errorf(codes.InvalidArgument, "capability is required")
+
+	id, denial, err := s.resolveIdentity(req.Token)
+	if err != nil {
+		return nil, err
+	}
+	if denial != nil {
+		return deniedResponse(denial), nil
+	}
+	resp := s.authorizeIdentity(id, req)
+	if id.apiKey != nil && resp.Authorized {
+		s.recordAPIKeyUsage(id.apiKey)
+	}
+	return resp, nil
+}
+
+// AuthorizeBatch authorizes the given token for each of the targets.
+func (s *Server) AuthorizeBatch(ctx context.Context, req *v1.AuthorizeBatchRequest) (*v1.AuthorizeBatchResponse, error) {
+	if req.Token == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "token is required")
+	}
+	if len(req.Targets) == 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "targets are required")
+	}
+	for i, t := range req.Targets {
+		if err := validateTarget(t.AccessResource, t.Capability); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "targets[%d]: %s", i, err)
+		}
+	}
+
+	id, denial, err := s.resolveIdentity(req.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	var resps []*v1.AuthorizeResponse
+	for _, t := range req.Targets {
+		if denial != nil {
+			resps = append(resps, deniedResponse(denial))
+			continue
+		}
+		resps = append(resps, s.authorizeIdentity(id, &v1.AuthorizeRequest{
+			Token:          req.Token,
+			AccessResource: t.AccessResource,
+			Capability:     t.Capability,
+			OrganizationId: t.OrganizationId,
+			ProjectId:      t.ProjectId,
+			ClientIp:       req.ClientIp,
+		}))
+	}
+	// Count the batch as a single request if any of the targets is authorized.
+	if denial == nil && id.apiKey != nil && slices.ContainsFunc(resps, func(r *v1.AuthorizeResponse) bool { return r.Authorized }) {
+		s.recordAPIKeyUsage(id.apiKey)
+	}
+	return &v1.AuthorizeBatchResponse{Responses: resps}, nil
+}
+
+func validateTarget(accessResource, capability string) error {
+	if accessResource == "" {
+		return fmt.Errorf("access resource is required")
+	}
+	if capability == "" {
+		return fmt.Errorf("capability is required")
 	}
+	return nil
+}
+
+// identity is the caller identified from a token. Either apiKey or user is set.
+type identity struct {
+	apiKey *cache.K
+
+	userID string
+	user   *cache.U
+}
 
+// resolveIdentity looks up the API key or introspects the token. It returns a denialError
+// if the token does not correspond to a known caller.
+func (s *Server) resolveIdentity(token string) (*identity, *denialError, error) {
 	// Check if the token is the API key.
-	key, ok := s.cache.GetAPIKeyBySecret(req.Token)
-	if ok {
+	if key, ok := s.cache.GetAPIKeyBySecret(token); ok {
+		return &identity{apiKey: key}, nil, nil
+	}
+
+	is, err := s.tokenIntrospector.TokenIntrospect(token)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "failed to introspect token: %v", err)
+	}
+
+	if !is.Active {
+		return nil, newDenialError(v1.DenialReason_DENIAL_REASON_TOKEN_INACTIVE, "token is not active"), nil
+	}
+
+	userID := userid.Normalize(is.Extra.Email)
+	u, ok := s.cache.GetUserByID(userID)
+	if !ok {
+		return nil, newDenialError(v1.DenialReason_DENIAL_REASON_USER_NOT_FOUND, "user %q not found", userID), nil
+	}
+	return &identity{userID: userID, user: u}, nil, nil
+}
+
+func (s *Server) authorizeIdentity(id *identity, req *v1.AuthorizeRequest) *v1.AuthorizeResponse {
+	if key := id.apiKey; key != nil {
 		project, found := s.cache.GetProjectByID(key.ProjectID)
 		if !found {
-			return &v1.AuthorizeResponse{Authorized: false}, nil
+			return deniedResponse(newDenialError(v1.DenialReason_DENIAL_REASON_PROJECT_NOT_FOUND, "project %q of the API key not found", key.ProjectID))
 		}
 		org, found := s.cache.GetOrganizationByID(key.OrganizationID)
 		if !found {
-			return &v1.AuthorizeResponse{Authorized: false}, nil
+			return deniedResponse(newDenialError(v1.DenialReason_DENIAL_REASON_ORGANIZATION_NOT_FOUND, "organization %q of the API key not found", key.OrganizationID))
+		}
+
+		// Check the access before filling the response so that a denied response does not carry
+		// the metadata of the organization and the project.
+		source, err := s.authorized(toScope(req), key.OrganizationRole, key.ProjectRole)
+		if err != nil {
+			return deniedResponse(err)
 		}
 
 		return &v1.AuthorizeResponse{
-			Authorized: s.authorized(toScope(req), key.OrganizationRole, key.ProjectRole),
+			Authorized: true,
 			User: &v1.User{
 				Id:         key.UserID,
 				InternalId: key.InternalUserID,
@@ -59,25 +163,20 @@ func (s *Server) Authorize(ctx context.Context, req *v1.AuthorizeRequest) (*v1.A
 			TenantId:                 key.TenantID,
 			ApiKeyId:                 key.KeyID,
 			ExcludedFromRateLimiting: key.ExcludedFromRateLimiting,
-		}, nil
-	}
-
-	is, err := s.tokenIntrospector.TokenIntrospect(req.Token)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to introspect token: %v", err)
-	}
-
-	if !is.Active {
-		return &v1.AuthorizeResponse{Authorized: false}, nil
+			GrantSource:              source,
+		}
 	}
 
-	userID := userid.Normalize(is.Extra.Email)
-	u, ok := s.cache.GetUserByID(userID)
-	if !ok {
-		return &v1.AuthorizeResponse{Authorized: false}, nil
-	}
+	userID, u := id.userID, id.user
 
+	// TODO: Enforce the IP allowlists of API keys and organizations once user-manager returns them.
+	// The check must cover every path, including the api.organizations one below, which has no
+	// associated project. That path should require the client IP to be allowed by all organizations
+	// of the user.
 	if strings.HasPrefix(req.AccessResource, "api.organizations") {
+		if err := s.requestedIDsInTenant(u.TenantID, req.OrganizationId, req.ProjectId); err != nil {
+			return deniedResponse(err)
+		}
 		// Do not check further as the resource is not project-scoped, and we cannot tell an associated project.
 		// We let the caller perform additional check.
 		return &v1.AuthorizeResponse{
@@ -89,22 +188,26 @@ func (s *Server) Authorize(ctx context.Context, req *v1.AuthorizeRequest) (*v1.A
 			Organization: &v1.Organization{},
 			Project:      &v1.Project{},
 			TenantId:     u.TenantID,
-		}, nil
+		}
 	}
 
-	pr, err := s.findAssociatedProjectAndRoles(userID, req.OrganizationId, req.ProjectId)
+	pr, err := s.findAssociatedProjectAndRoles(userID, u.TenantID, req.OrganizationId, req.ProjectId)
 	if err != nil {
-		// TODO(kenji): Return a more specific error?
-		return &v1.AuthorizeResponse{Authorized: false}, nil
+		return deniedResponse(err)
 	}
 
 	org, found := s.cache.GetOrganizationByID(pr.project.OrganizationID)
 	if !found {
-		return &v1.AuthorizeResponse{Authorized: false}, nil
+		return deniedResponse(newDenialError(v1.DenialReason_DENIAL_REASON_ORGANIZATION_NOT_FOUND, "organization %q not found", pr.project.OrganizationID))
+	}
+
+	source, err := s.authorized(toScope(req), pr.orgRole, pr.projectRole)
+	if err != nil {
+		return deniedResponse(err)
 	}
 
 	return &v1.AuthorizeResponse{
-		Authorized: s.authorized(toScope(req), pr.orgRole, pr.projectRole),
+		Authorized: true,
 		User: &v1.User{
 			Id:         userID,
 			InternalId: u.InternalID,
@@ -122,15 +225,18 @@ func (s *Server) Authorize(ctx context.Context, req *v1.AuthorizeRequest) (*v1.A
 				u.TenantID,
 			),
 		},
-		TenantId: u.TenantID,
-	}, nil
+		TenantId:    u.TenantID,
+		GrantSource: source,
+	}
 }
 
+// authorized checks if the roles allow the requested scope. It returns which of the roles granted
+// the access, or a denialError if the access is not allowed.
 func (s *Server) authorized(
 	requestScope string,
 	orgRole uv1.OrganizationRole,
 	projectRole uv1.ProjectRole,
-) bool {
+) (v1.GrantSource, error) {
 	// TODO(kenji): Implement the logic based on https://help.openai.com/en/articles/9186755-managing-your-work-in-the-api-platform-with-projects.
 	// Here is a snippet from the document:
 	//
@@ -163,35 +269,55 @@ func (s *Server) authorized(
 	// - A user that has the "member" role for P.
 	//
 	// A user with the "reader" role for O cannot perform inference on P unless the user is a project owner or a member.
-	var role string
+	//
+	// TODO: Support custom roles defined per organization. user-manager does not yet expose role
+	// definitions or custom role assignments to users and API keys, so only the built-in roles
+	// below can be resolved. Once it does, the cache should sync the definitions per organization
+	// and the matchers should be looked up by organization before falling back to roleScopesMatchers.
+	var roleName string
+	source := v1.GrantSource_GRANT_SOURCE_ORGANIZATION_ROLE
 	switch orgRole {
 	case uv1.OrganizationRole_ORGANIZATION_ROLE_OWNER:
-		role = "organizationOwner"
+		roleName = role.OrganizationOwner
 	case uv1.OrganizationRole_ORGANIZATION_ROLE_TENANT_SYSTEM:
-		role = "tenantSystem"
+		roleName = role.TenantSystem
 	case uv1.OrganizationRole_ORGANIZATION_ROLE_READER:
+		source = v1.GrantSource_GRANT_SOURCE_PROJECT_ROLE
 		switch projectRole {
 		case uv1.ProjectRole_PROJECT_ROLE_OWNER:
-			role = "projectOwner"
+			roleName = role.ProjectOwner
 		case uv1.ProjectRole_PROJECT_ROLE_MEMBER:
-			role = "projectMember"
+			roleName = role.ProjectMember
 		default:
-			return false
+			return v1.GrantSource_GRANT_SOURCE_UNSPECIFIED, newDenialError(v1.DenialReason_DENIAL_REASON_ROLE_NOT_FOUND, "no project role is assigned")
 		}
 	default:
-		return false
+		return v1.GrantSource_GRANT_SOURCE_UNSPECIFIED, newDenialError(v1.DenialReason_DENIAL_REASON_ROLE_NOT_FOUND, "no organization role is assigned")
 	}
 
-	allowedScopes, ok := s.roleScopesMapper[role]
+	allowedScopes, ok := s.roleScopesMatchers[roleName]
 	if !ok {
-		return false
+		return v1.GrantSource_GRANT_SOURCE_UNSPECIFIED, newDenialError(v1.DenialReason_DENIAL_REASON_ROLE_NOT_FOUND, "role %q is not configured", roleName)
 	}
-	for _, s := range allowedScopes {
-		if s == requestScope {
-			return true
-		}
+	if allowedScopes.Denied(requestScope) {
+		return v1.GrantSource_GRANT_SOURCE_UNSPECIFIED, newDenialError(v1.DenialReason_DENIAL_REASON_SCOPE_NOT_ALLOWED, "scope %q is explicitly denied for role %q", requestScope, roleName)
+	}
+	if allowedScopes.Match(requestScope) {
+		return source, nil
+	}
+	return v1.GrantSource_GRANT_SOURCE_UNSPECIFIED, newDenialError(v1.DenialReason_DENIAL_REASON_SCOPE_NOT_ALLOWED, "scope %q is not allowed for role %q", requestScope, roleName)
+}
+
+// hasOrganizationWideAccess returns true if the organization role grants access to every project
+// in the organization without being a member of the project.
+func hasOrganizationWideAccess(orgRole uv1.OrganizationRole) bool {
+	switch orgRole {
+	case uv1.OrganizationRole_ORGANIZATION_ROLE_OWNER,
+		uv1.OrganizationRole_ORGANIZATION_ROLE_TENANT_SYSTEM:
+		return true
+	default:
+		return false
 	}
-	return false
 }
 
 type projectAndRoles struct {
@@ -200,14 +326,11 @@ type projectAndRoles struct {
 	projectRole uv1.ProjectRole
 }
 
-func (s *Server) findAssociatedProjectAndRoles(userID, requestedOrgID, requestedProjectID string) (*projectAndRoles, error) {
-	// TODO(kenji): When an org ID or a project ID is specified,
-	// check if the specified resource belongs to the same tenant as the user.
-
+func (s *Server) findAssociatedProjectAndRoles(userID, tenantID, requestedOrgID, requestedProjectID string) (*projectAndRoles, error) {
 	userProjects := s.cache.GetProjectsByUserID(userID)
 	userOrgs := s.cache.GetOrganizationsByUserID(userID)
 
-	project, err := s.findAssociatedProject(userID, requestedOrgID, requestedProjectID, userProjects, userOrgs)
+	project, err := s.findAssociatedProject(tenantID, requestedOrgID, requestedProjectID, userProjects, userOrgs)
 	if err != nil {
 		return nil, err
 	}
@@ -228,7 +351,19 @@ func (s *Server) findAssociatedProjectAndRoles(userID, requestedOrgID, requested
 		}
 	}
 	if orgRole == uv1.OrganizationRole_ORGANIZATION_ROLE_UNSPECIFIED {
-		return nil, fmt.Errorf("organization role not found for organization %q", project.OrganizationID)
+		if requestedProjectID != "" {
+			// Do not reveal the organization of a project that the user cannot access.
+			return nil, newDenialError(v1.DenialReason_DENIAL_REASON_NOT_PROJECT_MEMBER, "user is not a member of project %q", requestedProjectID)
+		}
+		return nil, newDenialError(v1.DenialReason_DENIAL_REASON_ROLE_NOT_FOUND, "organization role not found for organization %q", project.OrganizationID)
+	}
+
+	// An explicitly requested project must be accessible to the user, either by the membership of the project
+	// or by the organization-wide role inherited from the project's organization.
+	if requestedProjectID != "" &&
+		projectRole == uv1.ProjectRole_PROJECT_ROLE_UNSPECIFIED &&
+		!hasOrganizationWideAccess(orgRole) {
+		return nil, newDenialError(v1.DenialReason_DENIAL_REASON_NOT_PROJECT_MEMBER, "user is not a member of project %q", project.ID)
 	}
 
 	return &projectAndRoles{
@@ -238,8 +373,12 @@ func (s *Server) findAssociatedProjectAndRoles(userID, requestedOrgID, requested
 	}, nil
 }
 
+// findAssociatedProject returns the project that the request is associated with.
+//
+// A requested project or organization that belongs to a tenant other than the user's
+// is treated as not found so that the response does not reveal its existence.
 func (s *Server) findAssociatedProject(
-	userID,
+	tenantID,
 	requestedOrgID,
 	requestedProjectID string,
 	userProjects []cache.PU,
@@ -248,24 +387,22 @@ func (s *Server) findAssociatedProject(
 	if requestedProjectID != "" {
 		// Use this project. Grab the role if the user belongs to the project and/or the project's organization.
 
-		// TODO(kenji): Check also if the project belongs to the user's tenant.
 		p, ok := s.cache.GetProjectByID(requestedProjectID)
-		if !ok {
-			return nil, fmt.Errorf("project %s not found", requestedProjectID)
+		if !ok || !s.organizationInTenant(p.OrganizationID, tenantID) {
+			return nil, newDenialError(v1.DenialReason_DENIAL_REASON_PROJECT_NOT_FOUND, "project %q not found", requestedProjectID)
 		}
 		// Return an error if the specifies the org ID in the request, but the org ID does not match the org ID
 		// of the project.
 		if requestedOrgID != "" && requestedOrgID != p.OrganizationID {
-			return nil, fmt.Errorf("invalid org ID (%q) and project ID (%q) combination", requestedOrgID, requestedProjectID)
+			return nil, newDenialError(v1.DenialReason_DENIAL_REASON_ORGANIZATION_MISMATCH, "invalid org ID (%q) and project ID (%q) combination", requestedOrgID, requestedProjectID)
 		}
 
 		return p, nil
 	}
 
 	if requestedOrgID != "" {
-		// TODO(kenji): Check also if the org belongs to the user's tenant.
-		if _, ok := s.cache.GetOrganizationByID(requestedOrgID); !ok {
-			return nil, fmt.Errorf("organization %s not found", requestedOrgID)
+		if !s.organizationInTenant(requestedOrgID, tenantID) {
+			return nil, newDenialError(v1.DenialReason_DENIAL_REASON_ORGANIZATION_NOT_FOUND, "organization %q not found", requestedOrgID)
 		}
 
 		var projects []cache.P
@@ -278,7 +415,7 @@ func (s *Server) findAssociatedProject(
 		projects = append(projects, s.cache.GetProjectsByOrganizationID(requestedOrgID)...)
 
 		if len(projects) == 0 {
-			return nil, fmt.Errorf("project not found in the organization %s", requestedOrgID)
+			return nil, newDenialError(v1.DenialReason_DENIAL_REASON_PROJECT_NOT_FOUND, "project not found in the organization %q", requestedOrgID)
 		}
 
 		return pickProject(projects), nil
@@ -294,12 +431,33 @@ func (s *Server) findAssociatedProject(
 	}
 
 	if len(projects) == 0 {
-		return nil, fmt.Errorf("unable to identify a project for the user")
+		return nil, newDenialError(v1.DenialReason_DENIAL_REASON_PROJECT_NOT_FOUND, "unable to identify a project for the user")
 	}
 
 	return pickProject(projects), nil
 }
 
+// requestedIDsInTenant returns a denialError if the requested organization or project belongs to
+// a tenant other than the user's. Like findAssociatedProject, it treats them as not found.
+func (s *Server) requestedIDsInTenant(tenantID, requestedOrgID, requestedProjectID string) error {
+	if requestedOrgID != "" && !s.organizationInTenant(requestedOrgID, tenantID) {
+		return newDenialError(v1.DenialReason_DENIAL_REASON_ORGANIZATION_NOT_FOUND, "organization %q not found", requestedOrgID)
+	}
+	if requestedProjectID != "" {
+		p, ok := s.cache.GetProjectByID(requestedProjectID)
+		if !ok || !s.organizationInTenant(p.OrganizationID, tenantID) {
+			return newDenialError(v1.DenialReason_DENIAL_REASON_PROJECT_NOT_FOUND, "project %q not found", requestedProjectID)
+		}
+	}
+	return nil
+}
+
+// organizationInTenant returns true if the organization exists and belongs to the tenant.
+func (s *Server) organizationInTenant(organizationID, tenantID string) bool {
+	o, ok := s.cache.GetOrganizationByID(organizationID)
+	return ok && o.TenantID == tenantID
+}
+
 // pickProject picks a project from the list. If there is a default project, pick it.
 func pickProject(projects []cache.P) *cache.P {
 	for _, p := range projects {
@@ -368,6 +526,40 @@ func assignedKubernetesEnvsInternal(
 	return envs
 }
 
+// denialError is an error that describes why a request is not authorized.
+type denialError struct {
+	reason v1.DenialReason
+	detail string
+}
+
+func newDenialError(reason v1.DenialReason, format string, args ...any) *denialError {
+	return &denialError{
+		reason: reason,
+		detail: fmt.Sprintf(format, args...),
+	}
+}
+
+// Error implements the error interface.
+func (e *denialError) Error() string {
+	return e.detail
+}
+
+// deniedResponse returns a response that denies the request for the given error.
+//
+// The response does not carry the user, the organization, or the project so that a caller
+// cannot learn about resources it has no access to.
+func deniedResponse(err error) *v1.AuthorizeResponse {
+	resp := &v1.AuthorizeResponse{
+		Authorized:   false,
+		DenialDetail: err.Error(),
+	}
+	var de *denialError
+	if errors.As(err, &de) {
+		resp.DenialReason = de.reason
+	}
+	return resp
+}
+
 func toScope(req *v1.AuthorizeRequest) string {
 	return fmt.Sprintf("%s.%s", req.AccessResource, req.Capability)
 }
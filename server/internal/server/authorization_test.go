@@ -2,7 +2,10 @@ package server
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"testing"
+	"time"
 
 	v1 "github.com/llmariner/rbac-manager/api/v1"
 	"github.com/llmariner/rbac-manager/server/internal/cache"
@@ -35,6 +38,7 @@ func TestAuthorize(t *testing.T) {
 		usersByID                map[string]*cache.U
 		is                       *token.Introspection
 		want                     bool
+		wantReason               v1.DenialReason
 	}{
 		{
 			name: "authorized with API key",
@@ -54,7 +58,8 @@ func TestAuthorize(t *testing.T) {
 			},
 			orgsByID: map[string]*cache.O{
 				"my-org": {
-					ID: "my-org",
+					ID:       "my-org",
+					TenantID: "t0",
 				},
 			},
 			projectsByID: map[string]*cache.P{
@@ -84,7 +89,8 @@ func TestAuthorize(t *testing.T) {
 			},
 			orgsByID: map[string]*cache.O{
 				"my-org": {
-					ID: "my-org",
+					ID:       "my-org",
+					TenantID: "t0",
 				},
 			},
 			projectsByID: map[string]*cache.P{
@@ -105,13 +111,16 @@ func TestAuthorize(t *testing.T) {
 			},
 			apikeys: map[string]*cache.K{
 				"keySecret": {
+					ProjectID:        "my-project",
+					OrganizationID:   "my-org",
 					OrganizationRole: uv1.OrganizationRole_ORGANIZATION_ROLE_UNSPECIFIED,
 					ProjectRole:      uv1.ProjectRole_PROJECT_ROLE_UNSPECIFIED,
 				},
 			},
 			orgsByID: map[string]*cache.O{
 				"my-org": {
-					ID: "my-org",
+					ID:       "my-org",
+					TenantID: "t0",
 				},
 			},
 			projectsByID: map[string]*cache.P{
@@ -120,8 +129,9 @@ func TestAuthorize(t *testing.T) {
 					OrganizationID:      "my-org",
 				},
 			},
-			usersByID: map[string]*cache.U{},
-			want:      false,
+			usersByID:  map[string]*cache.U{},
+			want:       false,
+			wantReason: v1.DenialReason_DENIAL_REASON_ROLE_NOT_FOUND,
 		},
 		{
 			name: "authorized with dex",
@@ -133,7 +143,8 @@ func TestAuthorize(t *testing.T) {
 			apikeys: map[string]*cache.K{},
 			orgsByID: map[string]*cache.O{
 				"my-org": {
-					ID: "my-org",
+					ID:       "my-org",
+					TenantID: "t0",
 				},
 			},
 			orgsByUserID: map[string][]cache.OU{
@@ -174,6 +185,173 @@ func TestAuthorize(t *testing.T) {
 			},
 			want: true,
 		},
+		{
+			name: "unauthorized with project in another tenant",
+			req: &v1.AuthorizeRequest{
+				Token:          "jwt",
+				AccessResource: "api.object",
+				Capability:     "read",
+				ProjectId:      "other-project",
+			},
+			apikeys: map[string]*cache.K{},
+			orgsByID: map[string]*cache.O{
+				"my-org": {
+					ID:       "my-org",
+					TenantID: "t0",
+				},
+				"other-org": {
+					ID:       "other-org",
+					TenantID: "t1",
+				},
+			},
+			orgsByUserID: map[string][]cache.OU{
+				"my-user": {
+					{
+						Role:           uv1.OrganizationRole_ORGANIZATION_ROLE_OWNER,
+						OrganizationID: "my-org",
+					},
+				},
+			},
+			projectsByID: map[string]*cache.P{
+				"other-project": {
+					ID:             "other-project",
+					OrganizationID: "other-org",
+				},
+			},
+			usersByID: map[string]*cache.U{
+				"my-user": {
+					ID:       "my-user",
+					TenantID: "t0",
+				},
+			},
+			is: &token.Introspection{
+				Active: true,
+				Extra: token.IntrospectionExtra{
+					Email: "my-user",
+				},
+			},
+			want:       false,
+			wantReason: v1.DenialReason_DENIAL_REASON_PROJECT_NOT_FOUND,
+		},
+		{
+			name: "authorized with organizations resource",
+			req: &v1.AuthorizeRequest{
+				Token:          "jwt",
+				AccessResource: "api.organizations",
+				Capability:     "read",
+				OrganizationId: "my-org",
+			},
+			apikeys: map[string]*cache.K{},
+			orgsByID: map[string]*cache.O{
+				"my-org": {
+					ID:       "my-org",
+					TenantID: "t0",
+				},
+				"other-org": {
+					ID:       "other-org",
+					TenantID: "t1",
+				},
+			},
+			projectsByID: map[string]*cache.P{
+				"other-project": {
+					ID:             "other-project",
+					OrganizationID: "other-org",
+				},
+			},
+			usersByID: map[string]*cache.U{
+				"my-user": {
+					ID:       "my-user",
+					TenantID: "t0",
+				},
+			},
+			is: &token.Introspection{
+				Active: true,
+				Extra: token.IntrospectionExtra{
+					Email: "my-user",
+				},
+			},
+			want: true,
+		},
+		{
+			name: "unauthorized with organizations resource and organization in another tenant",
+			req: &v1.AuthorizeRequest{
+				Token:          "jwt",
+				AccessResource: "api.organizations",
+				Capability:     "read",
+				OrganizationId: "other-org",
+			},
+			apikeys: map[string]*cache.K{},
+			orgsByID: map[string]*cache.O{
+				"my-org": {
+					ID:       "my-org",
+					TenantID: "t0",
+				},
+				"other-org": {
+					ID:       "other-org",
+					TenantID: "t1",
+				},
+			},
+			projectsByID: map[string]*cache.P{
+				"other-project": {
+					ID:             "other-project",
+					OrganizationID: "other-org",
+				},
+			},
+			usersByID: map[string]*cache.U{
+				"my-user": {
+					ID:       "my-user",
+					TenantID: "t0",
+				},
+			},
+			is: &token.Introspection{
+				Active: true,
+				Extra: token.IntrospectionExtra{
+					Email: "my-user",
+				},
+			},
+			want:       false,
+			wantReason: v1.DenialReason_DENIAL_REASON_ORGANIZATION_NOT_FOUND,
+		},
+		{
+			name: "unauthorized with organizations resource and project in another tenant",
+			req: &v1.AuthorizeRequest{
+				Token:          "jwt",
+				AccessResource: "api.organizations",
+				Capability:     "read",
+				ProjectId:      "other-project",
+			},
+			apikeys: map[string]*cache.K{},
+			orgsByID: map[string]*cache.O{
+				"my-org": {
+					ID:       "my-org",
+					TenantID: "t0",
+				},
+				"other-org": {
+					ID:       "other-org",
+					TenantID: "t1",
+				},
+			},
+			projectsByID: map[string]*cache.P{
+				"other-project": {
+					ID:             "other-project",
+					OrganizationID: "other-org",
+				},
+			},
+			usersByID: map[string]*cache.U{
+				"my-user": {
+					ID:       "my-user",
+					TenantID: "t0",
+				},
+			},
+			is: &token.Introspection{
+				Active: true,
+				Extra: token.IntrospectionExtra{
+					Email: "my-user",
+				},
+			},
+			want:       false,
+			wantReason: v1.DenialReason_DENIAL_REASON_PROJECT_NOT_FOUND,
+		},
 		{
 			name: "unauthorized with inactive token",
 			req: &v1.AuthorizeRequest{
@@ -188,7 +366,8 @@ func TestAuthorize(t *testing.T) {
 					Email: "my-user",
 				},
 			},
-			want: false,
+			want:       false,
+			wantReason: v1.DenialReason_DENIAL_REASON_TOKEN_INACTIVE,
 		},
 		{
 			name: "unauthorized with invalid user",
@@ -204,7 +383,8 @@ func TestAuthorize(t *testing.T) {
 					Email: "different-user",
 				},
 			},
-			want: false,
+			want:       false,
+			wantReason: v1.DenialReason_DENIAL_REASON_USER_NOT_FOUND,
 		},
 
 		{
@@ -221,7 +401,59 @@ func TestAuthorize(t *testing.T) {
 					Email: "my-user",
 				},
 			},
-			want: false,
+			want:       false,
+			wantReason: v1.DenialReason_DENIAL_REASON_USER_NOT_FOUND,
+		},
+		{
+			name: "unauthorized with scope not allowed for role",
+			req: &v1.AuthorizeRequest{
+				Token:          "jwt",
+				AccessResource: "api.different-object",
+				Capability:     "read",
+			},
+			apikeys: map[string]*cache.K{},
+			orgsByID: map[string]*cache.O{
+				"my-org": {
+					ID:       "my-org",
+					TenantID: "t0",
+				},
+			},
+			orgsByUserID: map[string][]cache.OU{
+				"my-user": {
+					{
+						Role:           uv1.OrganizationRole_ORGANIZATION_ROLE_OWNER,
+						OrganizationID: "my-org",
+					},
+				},
+			},
+			projectsByID: map[string]*cache.P{
+				"my-project": {
+					ID:             "my-project",
+					OrganizationID: "my-org",
+				},
+			},
+			projectsByOrganizationID: map[string][]cache.P{
+				"my-org": {
+					{
+						ID:             "my-project",
+						OrganizationID: "my-org",
+					},
+				},
+			},
+			usersByID: map[string]*cache.U{
+				"my-user": {
+					ID:       "my-user",
+					TenantID: "t0",
+				},
+			},
+			is: &token.Introspection{
+				Active: true,
+				Extra: token.IntrospectionExtra{
+					Email: "my-user",
+				},
+			},
+			want:       false,
+			wantReason: v1.DenialReason_DENIAL_REASON_SCOPE_NOT_ALLOWED,
 		},
 		{
 			name: "authorized with no-user",
@@ -233,7 +465,8 @@ func TestAuthorize(t *testing.T) {
 			apikeys: map[string]*cache.K{},
 			orgsByID: map[string]*cache.O{
 				"my-org": {
-					ID: "my-org",
+					ID:       "my-org",
+					TenantID: "t0",
 				},
 			},
 			orgsByUserID: map[string][]cache.OU{
@@ -266,17 +499,18 @@ func TestAuthorize(t *testing.T) {
 					Email: "my-user",
 				},
 			},
-			want: false,
+			want:       false,
+			wantReason: v1.DenialReason_DENIAL_REASON_USER_NOT_FOUND,
 		},
 	}
 
 	for _, tc := range tcs {
 		t.Run(tc.name, func(t *testing.T) {
-			srv := &Server{
-				tokenIntrospector: &fakeTokenIntrospector{
+			srv, err := New(
+				&fakeTokenIntrospector{
 					is: tc.is,
 				},
-				cache: &fakeCacheGetter{
+				&fakeCacheGetter{
 					apikeys:                  tc.apikeys,
 					orgsByID:                 tc.orgsByID,
 					orgsByUserID:             tc.orgsByUserID,
@@ -284,11 +518,20 @@ func TestAuthorize(t *testing.T) {
 					projectsByOrganizationID: tc.projectsByOrganizationID,
 					usersByID:                tc.usersByID,
 				},
-				roleScopesMapper: roleScopesMap,
-			}
+				roleScopesMap,
+			)
+			assert.NoError(t, err)
 			resp, err := srv.Authorize(context.Background(), tc.req)
 			assert.NoError(t, err)
 			assert.Equal(t, tc.want, resp.Authorized)
+			assert.Equal(t, tc.wantReason, resp.DenialReason)
+			if !tc.want {
+				assert.NotEmpty(t, resp.DenialDetail)
+				// A denied response does not leak the metadata of the resources.
+				assert.Nil(t, resp.User)
+				assert.Nil(t, resp.Organization)
+				assert.Nil(t, resp.Project)
+			}
 			excludedFromRateLimiting := false
 			if tc.apikeys != nil {
 				if k, ok := tc.apikeys[tc.req.Token]; ok {
@@ -300,13 +543,262 @@ func TestAuthorize(t *testing.T) {
 	}
 }
 
+func TestAuthorizeBatch(t *testing.T) {
+	roleScopesMap := map[string][]string{
+		"organizationOwner": {
+			"api.object.read",
+		},
+		"projectMember": {
+			"api.object.read",
+			"api.object.write",
+		},
+	}
+
+	org0 := cache.O{ID: "o0", TenantID: "t0"}
+	org1 := cache.O{ID: "o1", TenantID: "t0"}
+	project0 := cache.P{ID: "p0", OrganizationID: org0.ID}
+	project1 := cache.P{ID: "p1", OrganizationID: org1.ID}
+
+	introspector := &fakeTokenIntrospector{
+		is: &token.Introspection{
+			Active: true,
+			Extra: token.IntrospectionExtra{
+				Email: "u0",
+			},
+		},
+	}
+	srv, err := New(
+		introspector,
+		&fakeCacheGetter{
+			apikeys: map[string]*cache.K{},
+			orgsByID: map[string]*cache.O{
+				org0.ID: &org0,
+				org1.ID: &org1,
+			},
+			orgsByUserID: map[string][]cache.OU{
+				"u0": {
+					{
+						Role:           uv1.OrganizationRole_ORGANIZATION_ROLE_OWNER,
+						OrganizationID: org0.ID,
+					},
+					{
+						Role:           uv1.OrganizationRole_ORGANIZATION_ROLE_READER,
+						OrganizationID: org1.ID,
+					},
+				},
+			},
+			projectsByID: map[string]*cache.P{
+				project0.ID: &project0,
+				project1.ID: &project1,
+			},
+			projectsByOrganizationID: map[string][]cache.P{
+				org0.ID: {project0},
+				org1.ID: {project1},
+			},
+			projectsByUserID: map[string][]cache.PU{
+				"u0": {
+					{
+						Project: &project1,
+						Role:    uv1.ProjectRole_PROJECT_ROLE_MEMBER,
+					},
+				},
+			},
+			usersByID: map[string]*cache.U{
+				"u0": {
+					ID:       "u0",
+					TenantID: "t0",
+				},
+			},
+		},
+		roleScopesMap,
+	)
+	assert.NoError(t, err)
+
+	resp, err := srv.AuthorizeBatch(context.Background(), &v1.AuthorizeBatchRequest{
+		Token: "jwt",
+		Targets: []*v1.AuthorizeBatchRequest_Target{
+			{
+				AccessResource: "api.object",
+				Capability:     "read",
+				ProjectId:      project0.ID,
+			},
+			{
+				AccessResource: "api.object",
+				Capability:     "write",
+				ProjectId:      project0.ID,
+			},
+			{
+				AccessResource: "api.object",
+				Capability:     "write",
+				ProjectId:      project1.ID,
+			},
+			{
+				AccessResource: "api.object",
+				Capability:     "read",
+				ProjectId:      "unknown",
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Responses, 4)
+	want := []bool{true, false, true, false}
+	for i, r := range resp.Responses {
+		assert.Equal(t, want[i], r.Authorized, "target %d", i)
+	}
+	assert.Equal(t, project1.ID, resp.Responses[2].Project.Id)
+	assert.Equal(t, v1.GrantSource_GRANT_SOURCE_ORGANIZATION_ROLE, resp.Responses[0].GrantSource)
+	assert.Equal(t, v1.GrantSource_GRANT_SOURCE_PROJECT_ROLE, resp.Responses[2].GrantSource)
+	assert.Equal(t, 1, introspector.counter)
+
+	introspector.is = &token.Introspection{Active: false}
+	resp, err = srv.AuthorizeBatch(context.Background(), &v1.AuthorizeBatchRequest{
+		Token: "jwt",
+		Targets: []*v1.AuthorizeBatchRequest_Target{
+			{
+				AccessResource: "api.object",
+				Capability:     "read",
+			},
+			{
+				AccessResource: "api.object",
+				Capability:     "write",
+			},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, resp.Responses, 2)
+	for _, r := range resp.Responses {
+		assert.False(t, r.Authorized)
+	}
+
+	_, err = srv.AuthorizeBatch(context.Background(), &v1.AuthorizeBatchRequest{
+		Token: "jwt",
+		Targets: []*v1.AuthorizeBatchRequest_Target{
+			{
+				AccessResource: "api.object",
+			},
+		},
+	})
+	assert.Error(t, err)
+}
+
+func TestAuthorized(t *testing.T) {
+	srv, err := New(nil, &fakeCacheGetter{}, map[string][]string{
+		"organizationOwner": {
+			"api.*.read",
+			"api.fine_tuning.*",
+			"!api.fine_tuning.jobs.delete",
+		},
+		"projectMember": {
+			"api.files.read",
+			"api.k8s.namespaced.*",
+		},
+	})
+	assert.NoError(t, err)
+
+	tcs := []struct {
+		name        string
+		scope       string
+		orgRole     uv1.OrganizationRole
+		projectRole uv1.ProjectRole
+		want        v1.GrantSource
+		wantReason  v1.DenialReason
+	}{
+		{
+			name:    "wildcard in the middle",
+			scope:   "api.workspaces.notebooks.read",
+			orgRole: uv1.OrganizationRole_ORGANIZATION_ROLE_OWNER,
+			want:    v1.GrantSource_GRANT_SOURCE_ORGANIZATION_ROLE,
+		},
+		{
+			name:    "trailing wildcard",
+			scope:   "api.fine_tuning.jobs.write",
+			orgRole: uv1.OrganizationRole_ORGANIZATION_ROLE_OWNER,
+			want:    v1.GrantSource_GRANT_SOURCE_ORGANIZATION_ROLE,
+		},
+		{
+			name:       "deny pattern",
+			scope:      "api.fine_tuning.jobs.delete",
+			orgRole:    uv1.OrganizationRole_ORGANIZATION_ROLE_OWNER,
+			wantReason: v1.DenialReason_DENIAL_REASON_SCOPE_NOT_ALLOWED,
+		},
+		{
+			name:       "no matching pattern",
+			scope:      "api.files.write",
+			orgRole:    uv1.OrganizationRole_ORGANIZATION_ROLE_OWNER,
+			wantReason: v1.DenialReason_DENIAL_REASON_SCOPE_NOT_ALLOWED,
+		},
+		{
+			name:        "exact match for project member",
+			scope:       "api.files.read",
+			orgRole:     uv1.OrganizationRole_ORGANIZATION_ROLE_READER,
+			projectRole: uv1.ProjectRole_PROJECT_ROLE_MEMBER,
+			want:        v1.GrantSource_GRANT_SOURCE_PROJECT_ROLE,
+		},
+		{
+			name:        "wildcard for project member",
+			scope:       "api.k8s.namespaced.write",
+			orgRole:     uv1.OrganizationRole_ORGANIZATION_ROLE_READER,
+			projectRole: uv1.ProjectRole_PROJECT_ROLE_MEMBER,
+			want:        v1.GrantSource_GRANT_SOURCE_PROJECT_ROLE,
+		},
+		{
+			name:        "role not configured",
+			scope:       "api.files.read",
+			orgRole:     uv1.OrganizationRole_ORGANIZATION_ROLE_READER,
+			projectRole: uv1.ProjectRole_PROJECT_ROLE_OWNER,
+			wantReason:  v1.DenialReason_DENIAL_REASON_ROLE_NOT_FOUND,
+		},
+	}
+	for _, tc := range tcs {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := srv.authorized(tc.scope, tc.orgRole, tc.projectRole)
+			if tc.wantReason != v1.DenialReason_DENIAL_REASON_UNSPECIFIED {
+				var de *denialError
+				assert.True(t, errors.As(err, &de))
+				assert.Equal(t, tc.wantReason, de.reason)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+
+	_, err = New(nil, &fakeCacheGetter{}, map[string][]string{
+		"organizationOwner": {"api.fi*.read"},
+	})
+	assert.Error(t, err)
+}
+
 func TestFindAssociatedProjectAndRoles(t *testing.T) {
-	const userID = "u0"
+	const (
+		userID        = "u0"
+		defaultUserID = "u1"
+		noProjectUser = "u2"
+		tenantID      = "t0"
+		otherTenantID = "t1"
+	)
 	org0 := cache.O{
-		ID: "o0",
+		ID:       "o0",
+		TenantID: tenantID,
 	}
 	org1 := cache.O{
-		ID: "o1",
+		ID:       "o1",
+		TenantID: tenantID,
+	}
+	// org2 belongs to the tenant, but u0 is not a member.
+	org2 := cache.O{
+		ID:       "o2",
+		TenantID: tenantID,
+	}
+	// org3 has no project.
+	org3 := cache.O{
+		ID:       "o3",
+		TenantID: tenantID,
+	}
+	// otherOrg belongs to another tenant.
+	otherOrg := cache.O{
+		ID:       "o4",
+		TenantID: otherTenantID,
 	}
 	project0 := cache.P{
 		ID:                  "p0",
@@ -323,11 +815,32 @@ func TestFindAssociatedProjectAndRoles(t *testing.T) {
 		OrganizationID:      org1.ID,
 		KubernetesNamespace: "n2",
 	}
+	project3 := cache.P{
+		ID:             "p3",
+		OrganizationID: org2.ID,
+	}
+	// project6 belongs to o0, but u0 is not a member.
+	project6 := cache.P{
+		ID:             "p6",
+		OrganizationID: org0.ID,
+	}
+	defaultProject := cache.P{
+		ID:             "p4",
+		OrganizationID: org2.ID,
+		IsDefault:      true,
+	}
+	otherProject := cache.P{
+		ID:             "p5",
+		OrganizationID: otherOrg.ID,
+	}
 
 	cache := &fakeCacheGetter{
 		orgsByID: map[string]*cache.O{
-			org0.ID: &org0,
-			org1.ID: &org1,
+			org0.ID:     &org0,
+			org1.ID:     &org1,
+			org2.ID:     &org2,
+			org3.ID:     &org3,
+			otherOrg.ID: &otherOrg,
 		},
 		orgsByUserID: map[string][]cache.OU{
 			userID: {
@@ -339,16 +852,32 @@ func TestFindAssociatedProjectAndRoles(t *testing.T) {
 					Role:           uv1.OrganizationRole_ORGANIZATION_ROLE_OWNER,
 					OrganizationID: org1.ID,
 				},
+				{
+					Role:           uv1.OrganizationRole_ORGANIZATION_ROLE_OWNER,
+					OrganizationID: org3.ID,
+				},
+			},
+			defaultUserID: {
+				{
+					Role:           uv1.OrganizationRole_ORGANIZATION_ROLE_OWNER,
+					OrganizationID: org2.ID,
+				},
 			},
 		},
 		projectsByID: map[string]*cache.P{
-			project0.ID: &project0,
-			project1.ID: &project1,
-			project2.ID: &project2,
+			project0.ID:       &project0,
+			project1.ID:       &project1,
+			project2.ID:       &project2,
+			project3.ID:       &project3,
+			defaultProject.ID: &defaultProject,
+			otherProject.ID:   &otherProject,
+			project6.ID:       &project6,
 		},
 		projectsByOrganizationID: map[string][]cache.P{
-			org0.ID: {project0, project1},
-			org1.ID: {project2},
+			org0.ID:     {project0, project1, project6},
+			org1.ID:     {project2},
+			org2.ID:     {project3, defaultProject},
+			otherOrg.ID: {otherProject},
 		},
 		projectsByUserID: map[string][]cache.PU{
 			userID: {
@@ -365,17 +894,26 @@ func TestFindAssociatedProjectAndRoles(t *testing.T) {
 		usersByID: map[string]*cache.U{
 			userID: {
 				ID:       userID,
-				TenantID: "t0",
+				TenantID: tenantID,
+			},
+			defaultUserID: {
+				ID:       defaultUserID,
+				TenantID: tenantID,
+			},
+			noProjectUser: {
+				ID:       noProjectUser,
+				TenantID: tenantID,
 			},
 		},
 	}
 
 	tcs := []struct {
 		name               string
+		userID             string
 		requestedOrgID     string
 		requestedProjectID string
 		want               *projectAndRoles
-		wantErr            bool
+		wantReason         v1.DenialReason
 	}{
 		{
 			name:               "requested project id p0",
@@ -411,7 +949,42 @@ func TestFindAssociatedProjectAndRoles(t *testing.T) {
 			name:               "uknown requested project id",
 			requestedOrgID:     "",
 			requestedProjectID: "unknown",
-			wantErr:            true,
+			wantReason:         v1.DenialReason_DENIAL_REASON_PROJECT_NOT_FOUND,
+		},
+		{
+			name:               "requested project id in another tenant",
+			requestedOrgID:     "",
+			requestedProjectID: otherProject.ID,
+			wantReason:         v1.DenialReason_DENIAL_REASON_PROJECT_NOT_FOUND,
+		},
+		{
+			name:               "requested project id in another tenant with its org id",
+			requestedOrgID:     otherOrg.ID,
+			requestedProjectID: otherProject.ID,
+			wantReason:         v1.DenialReason_DENIAL_REASON_PROJECT_NOT_FOUND,
+		},
+		{
+			name:               "requested project id in an org without the user",
+			requestedOrgID:     "",
+			requestedProjectID: project3.ID,
+			wantReason:         v1.DenialReason_DENIAL_REASON_NOT_PROJECT_MEMBER,
+		},
+		{
+			name:               "requested project id without membership",
+			requestedOrgID:     "",
+			requestedProjectID: project6.ID,
+			wantReason:         v1.DenialReason_DENIAL_REASON_NOT_PROJECT_MEMBER,
+		},
+		{
+			name:               "requested project id without membership in an owned org",
+			userID:             defaultUserID,
+			requestedOrgID:     "",
+			requestedProjectID: project3.ID,
+			want: &projectAndRoles{
+				project:     &project3,
+				orgRole:     uv1.OrganizationRole_ORGANIZATION_ROLE_OWNER,
+				projectRole: uv1.ProjectRole_PROJECT_ROLE_UNSPECIFIED,
+			},
 		},
 		{
 			name:               "requested org id o0",
@@ -433,6 +1006,24 @@ func TestFindAssociatedProjectAndRoles(t *testing.T) {
 				projectRole: uv1.ProjectRole_PROJECT_ROLE_UNSPECIFIED,
 			},
 		},
+		{
+			name:               "unknown requested org id",
+			requestedOrgID:     "unknown",
+			requestedProjectID: "",
+			wantReason:         v1.DenialReason_DENIAL_REASON_ORGANIZATION_NOT_FOUND,
+		},
+		{
+			name:               "requested org id in another tenant",
+			requestedOrgID:     otherOrg.ID,
+			requestedProjectID: "",
+			wantReason:         v1.DenialReason_DENIAL_REASON_ORGANIZATION_NOT_FOUND,
+		},
+		{
+			name:               "requested org id without projects",
+			requestedOrgID:     org3.ID,
+			requestedProjectID: "",
+			wantReason:         v1.DenialReason_DENIAL_REASON_PROJECT_NOT_FOUND,
+		},
 		{
 			name:               "requested org id and project id",
 			requestedOrgID:     org0.ID,
@@ -447,7 +1038,7 @@ func TestFindAssociatedProjectAndRoles(t *testing.T) {
 			name:               "mismatching requested org id and project id",
 			requestedOrgID:     org0.ID,
 			requestedProjectID: project2.ID,
-			wantErr:            true,
+			wantReason:         v1.DenialReason_DENIAL_REASON_ORGANIZATION_MISMATCH,
 		},
 		{
 			name:               "no project id and org id",
@@ -459,6 +1050,24 @@ func TestFindAssociatedProjectAndRoles(t *testing.T) {
 				projectRole: uv1.ProjectRole_PROJECT_ROLE_OWNER,
 			},
 		},
+		{
+			name:               "no project id and org id with a default project",
+			userID:             defaultUserID,
+			requestedOrgID:     "",
+			requestedProjectID: "",
+			want: &projectAndRoles{
+				project:     &defaultProject,
+				orgRole:     uv1.OrganizationRole_ORGANIZATION_ROLE_OWNER,
+				projectRole: uv1.ProjectRole_PROJECT_ROLE_UNSPECIFIED,
+			},
+		},
+		{
+			name:               "no project id and org id for a user without projects",
+			userID:             noProjectUser,
+			requestedOrgID:     "",
+			requestedProjectID: "",
+			wantReason:         v1.DenialReason_DENIAL_REASON_PROJECT_NOT_FOUND,
+		},
 	}
 
 	for _, tc := range tcs {
@@ -466,9 +1075,19 @@ func TestFindAssociatedProjectAndRoles(t *testing.T) {
 			srv := &Server{
 				cache: cache,
 			}
-			resp, err := srv.findAssociatedProjectAndRoles(userID, tc.requestedOrgID, tc.requestedProjectID)
-			if tc.wantErr {
-				assert.Error(t, err)
+			uid := tc.userID
+			if uid == "" {
+				uid = userID
+			}
+			resp, err := srv.findAssociatedProjectAndRoles(uid, tenantID, tc.requestedOrgID, tc.requestedProjectID)
+			if tc.want == nil {
+				var de *denialError
+				assert.True(t, errors.As(err, &de))
+				assert.Equal(t, tc.wantReason, de.reason)
+				// The detail does not reveal the organization of a requested project.
+				if p, ok := srv.cache.GetProjectByID(tc.requestedProjectID); ok && p.OrganizationID != tc.requestedOrgID {
+					assert.NotContains(t, de.detail, fmt.Sprintf("%q", p.OrganizationID))
+				}
 				return
 			}
 			assert.NoError(t, err)
@@ -551,9 +1170,12 @@ func TestAssignedKubernetesEnvsInternal(t *testing.T) {
 
 type fakeTokenIntrospector struct {
 	is *token.Introspection
+
+	counter int
 }
 
 func (f *fakeTokenIntrospector) TokenIntrospect(token string) (*token.Introspection, error) {
+	f.counter++
 	return f.is, nil
 }
 
@@ -570,6 +1192,11 @@ type fakeCacheGetter struct {
 	projectsByUserID         map[string][]cache.PU
 
 	usersByID map[string]*cache.U
+
+	invalidatedAPIKeyIDs []string
+	invalidatedUserIDs   []string
+
+	lastSuccessfulSyncTime time.Time
 }
 
 func (c *fakeCacheGetter) GetAPIKeyBySecret(secret string) (*cache.K, bool) {
@@ -577,6 +1204,14 @@ func (c *fakeCacheGetter) GetAPIKeyBySecret(secret string) (*cache.K, bool) {
 	return k, ok
 }
 
+func (c *fakeCacheGetter) GetAPIKeyIDs() map[string]bool {
+	ids := map[string]bool{}
+	for _, k := range c.apikeys {
+		ids[k.KeyID] = true
+	}
+	return ids
+}
+
 func (c *fakeCacheGetter) GetClusterByRegistrationKey(key string) (*cache.C, bool) {
 	cl, ok := c.clusters[key]
 	return cl, ok
@@ -618,3 +1253,15 @@ func (c *fakeCacheGetter) GetUserByID(id string) (*cache.U, bool) {
 	u, ok := c.usersByID[id]
 	return u, ok
 }
+
+func (c *fakeCacheGetter) GetLastSuccessfulSyncTime() time.Time {
+	return c.lastSuccessfulSyncTime
+}
+
+func (c *fakeCacheGetter) InvalidateAPIKey(keyID string) {
+	c.invalidatedAPIKeyIDs = append(c.invalidatedAPIKeyIDs, keyID)
+}
+
+func (c *fakeCacheGetter) InvalidateUser(userID string) {
+	c.invalidatedUserIDs = append(c.invalidatedUserIDs, userID)
+}
@@ -0,0 +1,27 @@
+package server
+
+import (
+	"context"
+
+	v1 "github.com/llmariner/rbac-manager/api/v1"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// InvalidateAPIKey revokes the API key until the next cache sync.
+func (s *Server) InvalidateAPIKey(ctx context.Context, req *v1.InvalidateAPIKeyRequest) (*v1.InvalidateAPIKeyResponse, error) {
+	if req.ApiKeyId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "api key id is required")
+	}
+	s.cache.InvalidateAPIKey(req.ApiKeyId)
+	return &v1.InvalidateAPIKeyResponse{}, nil
+}
+
+// InvalidateUser revokes the user and the user's API keys until the next cache sync.
+func (s *Server) InvalidateUser(ctx context.Context, req *v1.InvalidateUserRequest) (*v1.InvalidateUserResponse, error) {
+	if req.UserId == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "user id is required")
+	}
+	s.cache.InvalidateUser(req.UserId)
+	return &v1.InvalidateUserResponse{}, nil
+}
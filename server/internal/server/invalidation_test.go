@@ -0,0 +1,30 @@
+package server
+
+import (
+	"context"
+	"testing"
+
+	v1 "github.com/llmariner/rbac-manager/api/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestInvalidate(t *testing.T) {
+	c := &fakeCacheGetter{}
+	srv, err := New(&fakeTokenIntrospector{}, c, nil)
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = srv.InvalidateAPIKey(ctx, &v1.InvalidateAPIKeyRequest{ApiKeyId: "k0"})
+	assert.NoError(t, err)
+	_, err = srv.InvalidateUser(ctx, &v1.InvalidateUserRequest{UserId: "u0"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"k0"}, c.invalidatedAPIKeyIDs)
+	assert.Equal(t, []string{"u0"}, c.invalidatedUserIDs)
+
+	_, err = srv.InvalidateAPIKey(ctx, &v1.InvalidateAPIKeyRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = srv.InvalidateUser(ctx, &v1.InvalidateUserRequest{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
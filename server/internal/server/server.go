@@ -4,9 +4,11 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"time"
 
 	v1 "github.com/llmariner/rbac-manager/api/v1"
 	"github.com/llmariner/rbac-manager/server/internal/cache"
+	"github.com/llmariner/rbac-manager/server/internal/scope"
 	"github.com/llmariner/rbac-manager/server/internal/token"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health"
@@ -16,6 +18,7 @@ import (
 
 type cacheGetter interface {
 	GetAPIKeyBySecret(secret string) (*cache.K, bool)
+	GetAPIKeyIDs() map[string]bool
 
 	GetClusterByRegistrationKey(key string) (*cache.C, bool)
 	GetClustersByTenantID(tenantID string) []cache.C
@@ -28,6 +31,18 @@ type cacheGetter interface {
 	GetProjectsByUserID(userID string) []cache.PU
 
 	GetUserByID(id string) (*cache.U, bool)
+
+	GetLastSuccessfulSyncTime() time.Time
+}
+
+type cacheInvalidator interface {
+	InvalidateAPIKey(keyID string)
+	InvalidateUser(userID string)
+}
+
+type cacheStore interface {
+	cacheGetter
+	cacheInvalidator
 }
 
 // TokenIntrospector inspects the token.
@@ -35,15 +50,31 @@ type TokenIntrospector interface {
 	TokenIntrospect(token string) (*token.Introspection, error)
 }
 
-// New returns a new Server.
-func New(ti TokenIntrospector, cache cacheGetter, roleScopes map[string][]string) *Server {
+// New returns a new Server. The scopes of each role are compiled here so that
+// matching a request scope does not need to parse the patterns.
+func New(ti TokenIntrospector, cache cacheStore, roleScopes map[string][]string) (*Server, error) {
+	matchers := map[string]*scope.Matcher{}
+	for role, scopes := range roleScopes {
+		m, err := scope.Compile(scopes)
+		if err != nil {
+			return nil, fmt.Errorf("role %q: %s", role, err)
+		}
+		matchers[role] = m
+	}
+
 	return &Server{
 		tokenIntrospector: ti,
 
 		cache: cache,
 
-		roleScopesMapper: roleScopes,
-	}
+		roleScopesMatchers: matchers,
+
+		apiKeyUsages: newAPIKeyUsageTracker(),
+
+		healthCheck: health.NewServer(),
+
+		now: time.Now,
+	}, nil
 }
 
 // Server implementes the gRPC interface.
@@ -54,9 +85,17 @@ type Server struct {
 
 	tokenIntrospector TokenIntrospector
 
-	cache cacheGetter
+	cache cacheStore
+
+	// roleScopesMatchers maps a role name to the matcher of its allowed scopes.
+	roleScopesMatchers map[string]*scope.Matcher
+
+	apiKeyUsages *apiKeyUsageTracker
 
-	roleScopesMapper map[string][]string
+	healthCheck *health.Server
+
+	// now returns the current time. It is replaced in tests.
+	now func() time.Time
 }
 
 // Run starts the gRPC server.
@@ -65,15 +104,22 @@ func (s *Server) Run(ctx context.Context, port int) error {
 	v1.RegisterRbacInternalServiceServer(serv, s)
 	reflection.Register(serv)
 
-	healthCheck := health.NewServer()
-	healthCheck.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
-	grpc_health_v1.RegisterHealthServer(serv, healthCheck)
+	grpc_health_v1.RegisterHealthServer(serv, s.healthCheck)
 
 	s.srv = serv
 
 	return listenAndServe(serv, port)
 }
 
+// SetServing sets the serving status reported by the gRPC health server.
+func (s *Server) SetServing(serving bool) {
+	if serving {
+		s.healthCheck.SetServingStatus("", grpc_health_v1.HealthCheckResponse_SERVING)
+	} else {
+		s.healthCheck.SetServingStatus("", grpc_health_v1.HealthCheckResponse_NOT_SERVING)
+	}
+}
+
 // GracefulStop stops the gRPC server gracefully.
 func (s *Server) GracefulStop() {
 	s.srv.GracefulStop()
@@ -5,12 +5,31 @@
 */
 
 import * as fm from "../../fetch.pb"
+export enum DenialReason {
+  DENIAL_REASON_UNSPECIFIED = "DENIAL_REASON_UNSPECIFIED",
+  DENIAL_REASON_TOKEN_INACTIVE = "DENIAL_REASON_TOKEN_INACTIVE",
+  DENIAL_REASON_USER_NOT_FOUND = "DENIAL_REASON_USER_NOT_FOUND",
+  DENIAL_REASON_PROJECT_NOT_FOUND = "DENIAL_REASON_PROJECT_NOT_FOUND",
+  DENIAL_REASON_ORGANIZATION_NOT_FOUND = "DENIAL_REASON_ORGANIZATION_NOT_FOUND",
+  DENIAL_REASON_ORGANIZATION_MISMATCH = "DENIAL_REASON_ORGANIZATION_MISMATCH",
+  DENIAL_REASON_ROLE_NOT_FOUND = "DENIAL_REASON_ROLE_NOT_FOUND",
+  DENIAL_REASON_SCOPE_NOT_ALLOWED = "DENIAL_REASON_SCOPE_NOT_ALLOWED",
+  DENIAL_REASON_NOT_PROJECT_MEMBER = "DENIAL_REASON_NOT_PROJECT_MEMBER",
+}
+
+export enum GrantSource {
+  GRANT_SOURCE_UNSPECIFIED = "GRANT_SOURCE_UNSPECIFIED",
+  GRANT_SOURCE_ORGANIZATION_ROLE = "GRANT_SOURCE_ORGANIZATION_ROLE",
+  GRANT_SOURCE_PROJECT_ROLE = "GRANT_SOURCE_PROJECT_ROLE",
+}
+
 export type AuthorizeRequest = {
   token?: string
   accessResource?: string
   capability?: string
   organizationId?: string
   projectId?: string
+  clientIp?: string
 }
 
 export type AuthorizeResponse = {
@@ -21,6 +40,26 @@ export type AuthorizeResponse = {
   tenantId?: string
   apiKeyId?: string
   excludedFromRateLimiting?: boolean
+  denialReason?: DenialReason
+  denialDetail?: string
+  grantSource?: GrantSource
+}
+
+export type AuthorizeBatchRequestTarget = {
+  accessResource?: string
+  capability?: string
+  organizationId?: string
+  projectId?: string
+}
+
+export type AuthorizeBatchRequest = {
+  token?: string
+  targets?: AuthorizeBatchRequestTarget[]
+  clientIp?: string
+}
+
+export type AuthorizeBatchResponse = {
+  responses?: AuthorizeResponse[]
 }
 
 export type AuthorizeWorkerRequest = {
@@ -60,11 +99,52 @@ export type Cluster = {
   name?: string
 }
 
+export type ListAPIKeyUsagesRequest = {
+  tenantId?: string
+}
+
+export type APIKeyUsage = {
+  apiKeyId?: string
+  tenantId?: string
+  lastUsedAt?: string
+  requestCount?: string
+}
+
+export type ListAPIKeyUsagesResponse = {
+  usages?: APIKeyUsage[]
+}
+
+export type InvalidateAPIKeyRequest = {
+  apiKeyId?: string
+}
+
+export type InvalidateAPIKeyResponse = {
+}
+
+export type InvalidateUserRequest = {
+  userId?: string
+}
+
+export type InvalidateUserResponse = {
+}
+
 export class RbacInternalService {
   static Authorize(req: AuthorizeRequest, initReq?: fm.InitReq): Promise<AuthorizeResponse> {
     return fm.fetchReq<AuthorizeRequest, AuthorizeResponse>(`/llmariner.rbac.server.v1.RbacInternalService/Authorize`, {...initReq, method: "POST", body: JSON.stringify(req)})
   }
+  static AuthorizeBatch(req: AuthorizeBatchRequest, initReq?: fm.InitReq): Promise<AuthorizeBatchResponse> {
+    return fm.fetchReq<AuthorizeBatchRequest, AuthorizeBatchResponse>(`/llmariner.rbac.server.v1.RbacInternalService/AuthorizeBatch`, {...initReq, method: "POST", body: JSON.stringify(req)})
+  }
   static AuthorizeWorker(req: AuthorizeWorkerRequest, initReq?: fm.InitReq): Promise<AuthorizeWorkerResponse> {
     return fm.fetchReq<AuthorizeWorkerRequest, AuthorizeWorkerResponse>(`/llmariner.rbac.server.v1.RbacInternalService/AuthorizeWorker`, {...initReq, method: "POST", body: JSON.stringify(req)})
   }
+  static ListAPIKeyUsages(req: ListAPIKeyUsagesRequest, initReq?: fm.InitReq): Promise<ListAPIKeyUsagesResponse> {
+    return fm.fetchReq<ListAPIKeyUsagesRequest, ListAPIKeyUsagesResponse>(`/llmariner.rbac.server.v1.RbacInternalService/ListAPIKeyUsages`, {...initReq, method: "POST", body: JSON.stringify(req)})
+  }
+  static InvalidateAPIKey(req: InvalidateAPIKeyRequest, initReq?: fm.InitReq): Promise<InvalidateAPIKeyResponse> {
+    return fm.fetchReq<InvalidateAPIKeyRequest, InvalidateAPIKeyResponse>(`/llmariner.rbac.server.v1.RbacInternalService/InvalidateAPIKey`, {...initReq, method: "POST", body: JSON.stringify(req)})
+  }
+  static InvalidateUser(req: InvalidateUserRequest, initReq?: fm.InitReq): Promise<InvalidateUserResponse> {
+    return fm.fetchReq<InvalidateUserRequest, InvalidateUserResponse>(`/llmariner.rbac.server.v1.RbacInternalService/InvalidateUser`, {...initReq, method: "POST", body: JSON.stringify(req)})
+  }
 }
\ No newline at end of file